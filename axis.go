@@ -133,6 +133,34 @@ func makeAxis(orientation bool) (Axis, error) {
 	return a, nil
 }
 
+// NewVerticalAxis returns a new Axis with the default
+// styles of the vertical axis of a Plot, for drawing
+// with DrawVertical.
+func NewVerticalAxis() (*Axis, error) {
+	a, err := makeAxis(vertical)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// VerticalWidth returns the width taken by the axis when drawn
+// vertically, as on the left of a Plot, including its padding
+// and half the width of its line.
+func (a *Axis) VerticalWidth() vg.Length {
+	va := verticalAxis{*a}
+	return va.size()
+}
+
+// DrawVertical draws the axis vertically with its line at x,
+// spanning the height of c. The label, tick labels and tick
+// marks are drawn to the left of the line.
+func (a *Axis) DrawVertical(c draw.Canvas, x vg.Length) {
+	va := verticalAxis{*a}
+	c.Min.X = x - va.lineOffset()
+	va.draw(c)
+}
+
 // sanitizeRange ensures that the range of the
 // axis makes sense.
 func (a *Axis) sanitizeRange() {
@@ -175,6 +203,38 @@ func (LogScale) Normalize(min, max, x float64) float64 {
 	return (log(x) - logMin) / (log(max) - logMin)
 }
 
+// InvertedScale can be used as the value of an Axis.Scale function
+// to invert the direction of the axis with the given scale, so that
+// Max is at the start of the axis and Min at the end.
+type InvertedScale struct {
+	Normalizer
+}
+
+var _ Normalizer = InvertedScale{}
+
+// Normalize returns the complement of the fractional
+// distance of x between min and max given by the
+// embedded Normalizer.
+func (s InvertedScale) Normalize(min, max, x float64) float64 {
+	return 1 - s.Normalizer.Normalize(min, max, x)
+}
+
+// ProbabilityScale can be used as the value of an Axis.Scale function
+// to set the axis to a normal probability scale, on which the cumulative
+// distribution function of a normal distribution is a straight line.
+// The values on the axis must be probabilities between 0 and 1,
+// exclusive.
+type ProbabilityScale struct{}
+
+var _ Normalizer = ProbabilityScale{}
+
+// Normalize returns the fractional distance of x between min and
+// max after transformation by the standard normal quantile function.
+func (ProbabilityScale) Normalize(min, max, x float64) float64 {
+	qMin := probit(min)
+	return (probit(x) - qMin) / (probit(max) - qMin)
+}
+
 // Norm returns the value of x, given in the data coordinate
 // system, normalized to its distance as a fraction of the
 // range of this axis.  For example, if x is a.Min then the return
@@ -296,6 +356,31 @@ func (a *verticalAxis) size() (w vg.Length) {
 	return
 }
 
+// lineOffset returns the distance from the left side of the
+// axis to its line as drawn by draw, when the major ticks
+// within the range of the axis are visible.
+func (a *verticalAxis) lineOffset() (x vg.Length) {
+	if a.Label.Text != "" {
+		x += a.Label.Height(a.Label.Text)
+		x -= a.Label.Font.Extents().Descent
+	}
+	marks := a.Tick.Marker.Ticks(a.Min, a.Max)
+	if len(marks) == 0 {
+		return x
+	}
+	x += tickLabelWidth(a.Tick.Label, marks)
+	for _, t := range marks {
+		if !t.IsMinor() && t.Value >= a.Min && t.Value <= a.Max {
+			x += a.Tick.Label.Width(" ")
+			break
+		}
+	}
+	if a.drawTicks() {
+		x += a.Tick.Length
+	}
+	return x
+}
+
 // draw draws the axis along the left side of a draw.Canvas.
 func (a *verticalAxis) draw(c draw.Canvas) {
 	x := c.Min.X
@@ -451,6 +536,36 @@ func (LogTicks) Ticks(min, max float64) []Tick {
 	return ticks
 }
 
+// ProbabilityTicks is suitable for the Tick.Marker field of an Axis,
+// it returns tick marks suitable for a probability-scale axis.
+type ProbabilityTicks struct{}
+
+var _ Ticker = ProbabilityTicks{}
+
+// probabilityTicks are the labelled tick values of ProbabilityTicks.
+var probabilityTicks = []float64{
+	0.0001, 0.001, 0.01, 0.05, 0.1, 0.25, 0.5, 0.75, 0.9, 0.95, 0.99, 0.999, 0.9999,
+}
+
+// Ticks returns Ticks in a specified range
+func (ProbabilityTicks) Ticks(min, max float64) []Tick {
+	if min <= 0 || max >= 1 {
+		panic("Values must be between 0 and 1 for a probability scale.")
+	}
+	var ticks []Tick
+	for _, v := range probabilityTicks {
+		if v >= min && v <= max {
+			ticks = append(ticks, Tick{Value: v, Label: formatFloatTick(v, displayPrecision)})
+		}
+	}
+	for _, v := range []float64{0.2, 0.3, 0.4, 0.6, 0.7, 0.8} {
+		if v >= min && v <= max {
+			ticks = append(ticks, Tick{Value: v})
+		}
+	}
+	return ticks
+}
+
 // ConstantTicks is suitable for the Tick.Marker field of an Axis.
 // This function returns the given set of ticks.
 type ConstantTicks []Tick
@@ -563,6 +678,14 @@ func log(x float64) float64 {
 	return math.Log(x)
 }
 
+// probit returns the standard normal quantile of p.
+func probit(p float64) float64 {
+	if p <= 0 || p >= 1 {
+		panic("Values must be between 0 and 1 for a probability scale.")
+	}
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
 // formatFloatTick returns a g-formated string representation of v
 // to the specified precision.
 func formatFloatTick(v float64, prec int) string {
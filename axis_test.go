@@ -8,6 +8,10 @@ import (
 	"math"
 	"reflect"
 	"testing"
+
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
 )
 
 func TestAxisSmallTick(t *testing.T) {
@@ -59,3 +63,79 @@ func labelsOf(ticks []Tick) []string {
 	}
 	return labels
 }
+
+func TestProbabilityScale(t *testing.T) {
+	var s ProbabilityScale
+	for _, test := range []struct {
+		x, want float64
+	}{
+		{0.01, 0},
+		{0.5, 0.5},
+		{0.99, 1},
+		{0.8413447460685429, 0.5 + 1/(2*2.3263478740408408)},
+	} {
+		if got := s.Normalize(0.01, 0.99, test.x); math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("unexpected normalized value for %v: got:%v want:%v", test.x, got, test.want)
+		}
+	}
+
+	var labels []string
+	for _, tick := range (ProbabilityTicks{}).Ticks(0.005, 0.995) {
+		if tick.Label != "" {
+			labels = append(labels, tick.Label)
+		}
+	}
+	want := []string{"0.01", "0.05", "0.1", "0.25", "0.5", "0.75", "0.9", "0.95", "0.99"}
+	if !reflect.DeepEqual(labels, want) {
+		t.Errorf("unexpected probability tick labels: got:%v want:%v", labels, want)
+	}
+}
+
+func TestInvertedScale(t *testing.T) {
+	for _, test := range []struct {
+		s       Normalizer
+		x, want float64
+	}{
+		{InvertedScale{LinearScale{}}, 1, 1},
+		{InvertedScale{LinearScale{}}, 3, 0},
+		{InvertedScale{LinearScale{}}, 2.5, 0.25},
+		{InvertedScale{InvertedScale{LinearScale{}}}, 2.5, 0.75},
+		{InvertedScale{LogScale{}}, math.Sqrt(3), 0.5},
+	} {
+		if got := test.s.Normalize(1, 3, test.x); math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("unexpected normalized value for %v: got:%v want:%v", test.x, got, test.want)
+		}
+	}
+}
+
+func TestDrawVertical(t *testing.T) {
+	a, err := NewVerticalAxis()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a.Min, a.Max = 0, 10
+	a.Label.Text = "label"
+	if got, want := a.VerticalWidth(), (&verticalAxis{*a}).size(); got != want {
+		t.Errorf("unexpected width: got:%v want:%v", got, want)
+	}
+
+	var r recorder.Canvas
+	x := vg.Length(100)
+	a.DrawVertical(draw.Canvas{
+		Canvas:    &r,
+		Rectangle: vg.Rectangle{Max: vg.Point{X: 200, Y: 200}},
+	}, x)
+	// The axis line is the last stroke.
+	var line *recorder.Stroke
+	for _, act := range r.Actions {
+		if s, ok := act.(*recorder.Stroke); ok {
+			line = s
+		}
+	}
+	if line == nil {
+		t.Fatal("no axis line drawn")
+	}
+	if got := line.Path[0].Pos.X; got != x {
+		t.Errorf("unexpected axis line position: got:%v want:%v", got, x)
+	}
+}
@@ -80,6 +80,16 @@ type DataRanger interface {
 	DataRange() (xmin, xmax, ymin, ymax float64)
 }
 
+// AxesHider wraps the HidesAxes method. Plotters that
+// draw their own axes, or for which Cartesian axes are
+// meaningless, may implement it to have the axes of a
+// plot hidden when they are added to it.
+type AxesHider interface {
+	// HidesAxes returns whether the axes of
+	// the plot should be hidden.
+	HidesAxes() bool
+}
+
 const (
 	vertical   = true
 	horizontal = false
@@ -124,7 +134,9 @@ func New() (*Plot, error) {
 // If the plotters implements DataRanger then the
 // minimum and maximum values of the X and Y
 // axes are changed if necessary to fit the range of
-// the data.
+// the data. If the plotters implements AxesHider and
+// reports that the axes should be hidden then the
+// axes are hidden.
 //
 // When drawing the plot, Plotters are drawn in the
 // order in which they were added to the plot.
@@ -137,6 +149,9 @@ func (p *Plot) Add(ps ...Plotter) {
 			p.Y.Min = math.Min(p.Y.Min, ymin)
 			p.Y.Max = math.Max(p.Y.Max, ymax)
 		}
+		if h, ok := d.(AxesHider); ok && h.HidesAxes() {
+			p.HideAxes()
+		}
 	}
 
 	p.plotters = append(p.plotters, ps...)
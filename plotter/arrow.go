@@ -0,0 +1,37 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// fillArrowHead fills a triangular arrow head of the given length
+// with its tip at tip, pointing in the direction from the point
+// from to the tip. Nothing is drawn if the two points coincide or
+// the tip is outside the canvas.
+func fillArrowHead(c *draw.Canvas, clr color.Color, from, tip vg.Point, length vg.Length) {
+	if length <= 0 || from == tip || !c.Contains(tip) {
+		return
+	}
+	d := tip.Sub(from)
+	theta := math.Atan2(float64(d.Y), float64(d.X))
+
+	// The head is drawn with a half angle of 20°.
+	const spread = 20 * math.Pi / 180
+	left := vg.Point{
+		X: tip.X - length*vg.Length(math.Cos(theta-spread)),
+		Y: tip.Y - length*vg.Length(math.Sin(theta-spread)),
+	}
+	right := vg.Point{
+		X: tip.X - length*vg.Length(math.Cos(theta+spread)),
+		Y: tip.Y - length*vg.Length(math.Sin(theta+spread)),
+	}
+	c.FillPolygon(clr, []vg.Point{tip, left, right})
+}
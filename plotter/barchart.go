@@ -45,6 +45,19 @@ type BarChart struct {
 	// locations and distances.
 	Horizontal bool
 
+	// YErrors, if not nil, holds the errors of the
+	// values, drawn as error bars at the end of each
+	// bar. They are set by SetYErrors.
+	YErrors YErrors
+
+	// ErrorStyle is the style of the error bars. If
+	// its color is nil, the color of LineStyle is used.
+	ErrorStyle draw.LineStyle
+
+	// CapWidth is the width of the caps of
+	// the error bars.
+	CapWidth vg.Length
+
 	// stackedOn is the bar chart upon which
 	// this bar chart is stacked.
 	stackedOn *BarChart
@@ -62,13 +75,32 @@ func NewBarChart(vs Valuer, width vg.Length) (*BarChart, error) {
 		return nil, err
 	}
 	return &BarChart{
-		Values:    values,
-		Width:     width,
-		Color:     color.Black,
-		LineStyle: DefaultLineStyle,
+		Values:     values,
+		Width:      width,
+		Color:      color.Black,
+		LineStyle:  DefaultLineStyle,
+		ErrorStyle: draw.LineStyle{Width: DefaultLineStyle.Width},
+		CapWidth:   DefaultCapWidth,
 	}, nil
 }
 
+// SetYErrors sets the errors of the values of the bar chart
+// to a copy of those of e, which are drawn as error bars
+// centered on the bars, including any Offset. The errors are
+// interpreted in the same way as by NewYErrorBars. The number
+// of errors must match the number of values.
+func (b *BarChart) SetYErrors(e interface {
+	YErrorer
+	Len() int
+}) error {
+	errs, err := copyYErrors(e, len(b.Values))
+	if err != nil {
+		return err
+	}
+	b.YErrors = errs
+	return nil
+}
+
 // BarHeight returns the maximum y value of the
 // ith bar, taking into account any bars upon
 // which it is stacked.
@@ -120,37 +152,60 @@ func (b *BarChart) Plot(c draw.Canvas, plt *plot.Plot) {
 		valMin := trVal(bottom)
 		valMax := trVal(bottom + ht)
 
-		var pts []vg.Point
-		var poly []vg.Point
-		if !b.Horizontal {
-			pts = []vg.Point{
-				{catMin, valMin},
-				{catMin, valMax},
-				{catMax, valMax},
-				{catMax, valMin},
-			}
-			poly = c.ClipPolygonY(pts)
-		} else {
-			pts = []vg.Point{
-				{valMin, catMin},
-				{valMin, catMax},
-				{valMax, catMax},
-				{valMax, catMin},
+		drawBar(c, b.Horizontal, catMin, catMax, valMin, valMax, b.Color, b.LineStyle)
+
+		if b.YErrors != nil {
+			sty := b.ErrorStyle
+			if sty.Color == nil {
+				sty.Color = b.LineStyle.Color
 			}
-			poly = c.ClipPolygonX(pts)
+			lo, hi := b.errorRange(i)
+			drawErrorBar(&c, sty, b.CapWidth, catMin+b.Width/2, trVal(lo), trVal(hi), b.Horizontal)
 		}
-		c.FillPolygon(b.Color, poly)
+	}
+}
 
-		var outline [][]vg.Point
-		if !b.Horizontal {
-			pts = append(pts, vg.Point{X: catMin, Y: valMin})
-			outline = c.ClipLinesY(pts)
-		} else {
-			pts = append(pts, vg.Point{X: valMin, Y: catMin})
-			outline = c.ClipLinesX(pts)
+// errorRange returns the ends of the error
+// bar of the ith bar.
+func (b *BarChart) errorRange(i int) (lo, hi float64) {
+	top := b.BarHeight(i)
+	return top - math.Abs(b.YErrors[i].Low), top + math.Abs(b.YErrors[i].High)
+}
+
+// drawBar draws a single filled and outlined bar spanning catMin
+// to catMax across the bar and valMin to valMax along it. If
+// horizontal is true the bar is drawn along the X axis.
+func drawBar(c draw.Canvas, horizontal bool, catMin, catMax, valMin, valMax vg.Length, fill color.Color, sty draw.LineStyle) {
+	var pts []vg.Point
+	var poly []vg.Point
+	if !horizontal {
+		pts = []vg.Point{
+			{catMin, valMin},
+			{catMin, valMax},
+			{catMax, valMax},
+			{catMax, valMin},
+		}
+		poly = c.ClipPolygonY(pts)
+	} else {
+		pts = []vg.Point{
+			{valMin, catMin},
+			{valMin, catMax},
+			{valMax, catMax},
+			{valMax, catMin},
 		}
-		c.StrokeLines(b.LineStyle, outline...)
+		poly = c.ClipPolygonX(pts)
 	}
+	c.FillPolygon(fill, poly)
+
+	var outline [][]vg.Point
+	if !horizontal {
+		pts = append(pts, vg.Point{X: catMin, Y: valMin})
+		outline = c.ClipLinesY(pts)
+	} else {
+		pts = append(pts, vg.Point{X: valMin, Y: catMin})
+		outline = c.ClipLinesX(pts)
+	}
+	c.StrokeLines(sty, outline...)
 }
 
 // DataRange implements the plot.DataRanger interface.
@@ -165,6 +220,10 @@ func (b *BarChart) DataRange() (xmin, xmax, ymin, ymax float64) {
 		valTop := valBot + val
 		valMin = math.Min(valMin, math.Min(valBot, valTop))
 		valMax = math.Max(valMax, math.Max(valBot, valTop))
+		if b.YErrors != nil {
+			lo, hi := b.errorRange(i)
+			valMin, valMax = math.Min(valMin, lo), math.Max(valMax, hi)
+		}
 	}
 	if !b.Horizontal {
 		return catMin, catMax, valMin, valMax
@@ -7,6 +7,7 @@ package plotter
 import (
 	"image/color"
 	"log"
+	"math"
 	"testing"
 
 	"github.com/gonum/plot"
@@ -171,3 +172,88 @@ func TestBarChart(t *testing.T) {
 		"horizontalBarChart.png", "barChart2.png",
 		"stackedBarChart.png")
 }
+
+// ExampleBarChart_errors draws grouped bars
+// with error bars aligned to their offsets.
+func ExampleBarChart_errors() {
+	groupA := Values{20, 35, 30, 35, 27}
+	groupB := Values{25, 32, 34, 20, 25}
+	errsA := YErrors{{2, 2}, {3, 4}, {1, 1}, {5, 3}, {2, 2}}
+	errsB := YErrors{{1, 3}, {2, 2}, {4, 4}, {2, 1}, {3, 3}}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Bar chart with errors"
+	p.Y.Label.Text = "Heights"
+
+	w := vg.Points(12)
+
+	barsA, err := NewBarChart(groupA, w)
+	if err != nil {
+		log.Panic(err)
+	}
+	barsA.Color = color.RGBA{R: 255, A: 255}
+	barsA.Offset = -w / 2
+	if err = barsA.SetYErrors(errsA); err != nil {
+		log.Panic(err)
+	}
+
+	barsB, err := NewBarChart(groupB, w)
+	if err != nil {
+		log.Panic(err)
+	}
+	barsB.Color = color.RGBA{R: 196, G: 196, A: 255}
+	barsB.Offset = w / 2
+	if err = barsB.SetYErrors(errsB); err != nil {
+		log.Panic(err)
+	}
+
+	p.Add(barsA, barsB)
+	p.Legend.Add("A", barsA)
+	p.Legend.Add("B", barsB)
+	p.Legend.Top = true
+	p.NominalX("Zero", "One", "Two", "Three", "Four")
+	p.X.Min, p.X.Max = -0.5, 4.5
+	p.Y.Max = 48
+
+	err = p.Save(250, 250, "testdata/barChartErrors.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestBarChartErrors(t *testing.T) {
+	checkPlot(ExampleBarChart_errors, t, "barChartErrors.png")
+}
+
+func TestBarChartErrorsRange(t *testing.T) {
+	b, err := NewBarChart(Values{1, 4}, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = b.SetYErrors(YErrors{{2, 1}, {-1, -3}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, _, ymin, ymax := b.DataRange()
+	if ymin != -1 || ymax != 7 {
+		t.Errorf("unexpected range: got:(%v, %v) want:(-1, 7)", ymin, ymax)
+	}
+
+	err = b.SetYErrors(YErrors{{math.NaN(), 1}, {1, 1}})
+	if err != ErrNaN {
+		t.Errorf("unexpected error for NaN error: got:%v want:%v", err, ErrNaN)
+	}
+	if err = b.SetYErrors(YErrors{{1, 1}}); err == nil {
+		t.Error("expected error for too few errors")
+	}
+
+	b.CapWidth = 2
+	if err = b.SetYErrors(YErrors{{1, 1}, {1, 1}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.CapWidth != 2 {
+		t.Errorf("unexpected cap width: got:%v want:2", b.CapWidth)
+	}
+}
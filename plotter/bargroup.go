@@ -0,0 +1,319 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// BarGroupMode specifies how the series
+// of a BarGroup are arranged.
+type BarGroupMode int
+
+const (
+	// Grouped draws the bars of the series
+	// side by side within each category.
+	Grouped BarGroupMode = iota
+
+	// Stacked stacks the bars of the series within
+	// each category, with positive values stacked
+	// above zero and negative values below.
+	Stacked
+
+	// PercentStacked stacks the bars like Stacked,
+	// with each value expressed as a percentage of
+	// the total magnitude of its category.
+	PercentStacked
+)
+
+// BarGroup implements the Plotter interface, drawing several
+// series of values as bars in categories, grouped or stacked.
+// The bars of category i are centered at XMin+i, and their
+// widths are computed from the spacing of the categories on the
+// canvas when drawn.
+type BarGroup struct {
+	// Series holds the values of each series,
+	// one value for each category. In
+	// PercentStacked mode each value is drawn
+	// as a percentage of the total magnitude
+	// of its category.
+	Series []Values
+
+	// Names holds the name of each series,
+	// used for legend entries.
+	Names []string
+
+	// Mode is the arrangement of the series.
+	Mode BarGroupMode
+
+	// Colors holds the fill color of each series.
+	Colors []color.Color
+
+	// LineStyle is the style of the outline of the bars.
+	draw.LineStyle
+
+	// Fraction is the fraction of the spacing of the
+	// categories covered by the bars of a category.
+	Fraction float64
+
+	// XMin is the location of the first category.
+	XMin float64
+
+	// Horizontal dictates whether the bars are drawn
+	// along the X axis rather than the Y axis.
+	Horizontal bool
+
+	// LabelFormat, if not empty, is the format used
+	// to label each bar with its value. Grouped bars
+	// are labelled beyond their ends and stacked bars
+	// in their centers, if the label fits.
+	LabelFormat string
+
+	// TextStyle is the style of the value labels.
+	TextStyle draw.TextStyle
+}
+
+// NewBarGroup returns a BarGroup of the series, which must
+// all have the same number of values, arranged in the given
+// mode. The series are filled with shades of gray.
+func NewBarGroup(series []Valuer, names []string, mode BarGroupMode) (*BarGroup, error) {
+	if len(series) == 0 {
+		return nil, ErrNoData
+	}
+	if names != nil && len(names) != len(series) {
+		return nil, errors.New("Number of names does not match the number of series")
+	}
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	g := &BarGroup{
+		Series:    make([]Values, len(series)),
+		Names:     names,
+		Mode:      mode,
+		Colors:    make([]color.Color, len(series)),
+		LineStyle: DefaultLineStyle,
+		Fraction:  0.8,
+		TextStyle: draw.TextStyle{Font: fnt},
+	}
+	for s, vs := range series {
+		if vs.Len() != series[0].Len() {
+			return nil, errors.New("Series lengths differ")
+		}
+		if g.Series[s], err = CopyValues(vs); err != nil {
+			return nil, err
+		}
+		g.Colors[s] = color.Gray{Y: uint8(64 + 160*s/len(series))}
+	}
+	return g, nil
+}
+
+// AddTo adds the BarGroup to the plot, with
+// a legend entry for each named series.
+func (g *BarGroup) AddTo(p *plot.Plot) {
+	p.Add(g)
+	for s, name := range g.Names {
+		p.Legend.Add(name, barGroupSeries{g, s})
+	}
+}
+
+// barGroupSeries is the legend entry of
+// a series of a BarGroup.
+type barGroupSeries struct {
+	*BarGroup
+	series int
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (s barGroupSeries) Thumbnail(c *draw.Canvas) {
+	b := BarChart{Color: s.Colors[s.series], LineStyle: s.LineStyle}
+	b.Thumbnail(c)
+}
+
+// value returns the value of series s in category i as
+// drawn, which in PercentStacked mode is a percentage of
+// the total magnitude of the category.
+func (g *BarGroup) value(s, i int) float64 {
+	v := g.Series[s][i]
+	if g.Mode != PercentStacked {
+		return v
+	}
+	var total float64
+	for _, vs := range g.Series {
+		total += math.Abs(vs[i])
+	}
+	if total == 0 {
+		return v
+	}
+	return v * 100 / total
+}
+
+// extents returns the ends of the bar of series s in
+// category i along the value axis. Stacked bars start
+// at the end of the previous bar of the same sign.
+func (g *BarGroup) extents(s, i int) (lo, hi float64) {
+	v := g.value(s, i)
+	if g.Mode == Grouped {
+		return 0, v
+	}
+	var base float64
+	for r := range g.Series[:s] {
+		if u := g.value(r, i); (u < 0) == (v < 0) {
+			base += u
+		}
+	}
+	return base, base + v
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (g *BarGroup) Plot(c draw.Canvas, plt *plot.Plot) {
+	trCat, trVal := plt.Transforms(&c)
+	if g.Horizontal {
+		trCat, trVal = trVal, trCat
+	}
+	point := func(cat, val vg.Length) vg.Point {
+		if g.Horizontal {
+			return vg.Point{X: val, Y: cat}
+		}
+		return vg.Point{X: cat, Y: val}
+	}
+
+	n := len(g.Series)
+	width := vg.Length(g.Fraction) * (trCat(g.XMin+1) - trCat(g.XMin))
+	if width < 0 {
+		width = -width
+	}
+	if g.Mode == Grouped {
+		width /= vg.Length(n)
+	}
+
+	for s, vs := range g.Series {
+		var offset vg.Length
+		if g.Mode == Grouped {
+			offset = (vg.Length(s) - vg.Length(n-1)/2) * width
+		}
+		for i := range vs {
+			v := g.value(s, i)
+			cat := trCat(g.XMin+float64(i)) + offset
+			lo, hi := g.extents(s, i)
+			valMin, valMax := trVal(lo), trVal(hi)
+			drawBar(c, g.Horizontal, cat-width/2, cat+width/2, valMin, valMax, g.Colors[s], g.LineStyle)
+
+			if g.LabelFormat == "" {
+				continue
+			}
+			label := fmt.Sprintf(g.LabelFormat, v)
+			sty := g.TextStyle
+			sty.XAlign, sty.YAlign = draw.XCenter, draw.YCenter
+			if g.Mode == Grouped {
+				pt := point(cat, valMax)
+				if !c.Contains(pt) {
+					continue
+				}
+				// Place the label beyond the end of the bar.
+				pad := g.TextStyle.Height(label) / 4
+				switch {
+				case g.Horizontal && v >= 0:
+					sty.XAlign = draw.XLeft
+					pt.X += pad
+				case g.Horizontal:
+					sty.XAlign = draw.XRight
+					pt.X -= pad
+				case v >= 0:
+					sty.YAlign = draw.YBottom
+					pt.Y += pad
+				default:
+					sty.YAlign = draw.YTop
+					pt.Y -= pad
+				}
+				c.FillText(sty, pt, label)
+				continue
+			}
+
+			length := valMax - valMin
+			if length < 0 {
+				length = -length
+			}
+			across, along := sty.Width(label), sty.Height(label)
+			if g.Horizontal {
+				across, along = along, across
+			}
+			if pt := point(cat, (valMin+valMax)/2); along <= length && across <= width && c.Contains(pt) {
+				c.FillText(sty, pt, label)
+			}
+		}
+	}
+}
+
+// DataRange implements the DataRange method of the
+// plot.DataRanger interface. The range of the categories
+// extends half a category beyond the first and last,
+// so that the bars are within the range.
+func (g *BarGroup) DataRange() (xmin, xmax, ymin, ymax float64) {
+	catMin := g.XMin - 0.5
+	catMax := g.XMin + float64(len(g.Series[0])-1) + 0.5
+	var valMin, valMax float64
+	for s, vs := range g.Series {
+		for i := range vs {
+			lo, hi := g.extents(s, i)
+			valMin = math.Min(valMin, math.Min(lo, hi))
+			valMax = math.Max(valMax, math.Max(lo, hi))
+		}
+	}
+	if g.Horizontal {
+		return valMin, valMax, catMin, catMax
+	}
+	return catMin, catMax, valMin, valMax
+}
+
+// GlyphBoxes implements the GlyphBoxes method of the
+// plot.GlyphBoxer interface, reserving space for the
+// labels beyond the ends of grouped bars.
+func (g *BarGroup) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	if g.Mode != Grouped || g.LabelFormat == "" {
+		return nil
+	}
+	var bs []plot.GlyphBox
+	for _, vs := range g.Series {
+		for i, v := range vs {
+			label := fmt.Sprintf(g.LabelFormat, v)
+			w, h := g.TextStyle.Width(label), g.TextStyle.Height(label)
+			ext := h/4 + h
+			if g.Horizontal {
+				ext = h/4 + w
+			}
+			var lo, hi vg.Length
+			if v < 0 {
+				lo = -ext
+			} else {
+				hi = ext
+			}
+			cat := g.XMin + float64(i)
+			var b plot.GlyphBox
+			if g.Horizontal {
+				b.X, b.Y = plt.X.Norm(v), plt.Y.Norm(cat)
+				b.Rectangle = vg.Rectangle{
+					Min: vg.Point{X: lo, Y: -h / 2},
+					Max: vg.Point{X: hi, Y: h / 2},
+				}
+			} else {
+				b.X, b.Y = plt.X.Norm(cat), plt.Y.Norm(v)
+				b.Rectangle = vg.Rectangle{
+					Min: vg.Point{X: -w / 2, Y: lo},
+					Max: vg.Point{X: w / 2, Y: hi},
+				}
+			}
+			bs = append(bs, b)
+		}
+	}
+	return bs
+}
@@ -0,0 +1,148 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"github.com/gonum/plot"
+)
+
+var barGroupColors = []color.Color{
+	color.RGBA{R: 51, G: 102, B: 204, A: 255},
+	color.RGBA{R: 230, G: 140, B: 30, A: 255},
+	color.RGBA{R: 51, G: 153, B: 51, A: 255},
+}
+
+// ExampleBarGroup draws grouped bars with
+// value labels, including negative values.
+func ExampleBarGroup() {
+	series := []Valuer{
+		Values{12, 15, 9, 14},
+		Values{8, -3, 11, 6},
+		Values{5, 7, -4, 10},
+	}
+	g, err := NewBarGroup(series, []string{"North", "South", "West"}, Grouped)
+	if err != nil {
+		log.Panic(err)
+	}
+	g.Colors = barGroupColors
+	g.LabelFormat = "%.0f"
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Grouped bars"
+	p.Y.Label.Text = "Sales"
+	g.AddTo(p)
+	p.NominalX("Q1", "Q2", "Q3", "Q4")
+	p.Legend.Top = true
+	p.Y.Max = 24
+
+	err = p.Save(250, 200, "testdata/barGroup.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestBarGroup(t *testing.T) {
+	checkPlot(ExampleBarGroup, t, "barGroup.png")
+}
+
+// ExampleBarGroup_percent draws horizontal
+// percent-stacked bars.
+func ExampleBarGroup_percent() {
+	series := []Valuer{
+		Values{30, 55, 20},
+		Values{50, 25, 20},
+		Values{20, 20, 60},
+	}
+	g, err := NewBarGroup(series, []string{"Compute", "Storage", "Network"}, PercentStacked)
+	if err != nil {
+		log.Panic(err)
+	}
+	g.Colors = barGroupColors
+	g.Horizontal = true
+	g.LabelFormat = "%.0f%%"
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Cost breakdown"
+	p.X.Label.Text = "Percent"
+	g.AddTo(p)
+	p.NominalY("Team A", "Team B", "Team C")
+	p.Legend.Top = true
+	p.Legend.Left = true
+	p.Y.Max += 1
+
+	err = p.Save(250, 200, "testdata/barGroupPercent.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestBarGroupPercent(t *testing.T) {
+	checkPlot(ExampleBarGroup_percent, t, "barGroupPercent.png")
+}
+
+func TestBarGroupExtents(t *testing.T) {
+	series := []Valuer{Values{1, -2}, Values{3, 4}, Values{-1, -2}}
+	g, err := NewBarGroup(series, nil, Stacked)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := [][2][2]float64{
+		{{0, 1}, {0, -2}},
+		{{1, 4}, {0, 4}},
+		{{0, -1}, {-2, -4}},
+	}
+	for s := range series {
+		for i := range want[s] {
+			lo, hi := g.extents(s, i)
+			if lo != want[s][i][0] || hi != want[s][i][1] {
+				t.Errorf("unexpected extents of series %d category %d: got:[%v, %v] want:%v",
+					s, i, lo, hi, want[s][i])
+			}
+		}
+	}
+	xmin, xmax, ymin, ymax := g.DataRange()
+	if xmin != -0.5 || xmax != 1.5 || ymin != -4 || ymax != 4 {
+		t.Errorf("unexpected data range: got:[%v, %v]×[%v, %v]", xmin, xmax, ymin, ymax)
+	}
+
+	// The percentages follow the current mode,
+	// and the series keep their raw values.
+	g.Mode = PercentStacked
+	for i := range g.Series[0] {
+		var total float64
+		for s := range g.Series {
+			total += math.Abs(g.value(s, i))
+		}
+		if math.Abs(total-100) > 1e-12 {
+			t.Errorf("unexpected percentage total in category %d: got:%v want:100", i, total)
+		}
+	}
+	if _, _, _, ymax := g.DataRange(); math.Abs(ymax-80) > 1e-12 {
+		t.Errorf("unexpected percent stacked maximum: got:%v want:80", ymax)
+	}
+	if g.Series[1][0] != 3 {
+		t.Errorf("unexpected series value: got:%v want:3", g.Series[1][0])
+	}
+	g.Mode = Stacked
+	if _, _, _, ymax := g.DataRange(); ymax != 4 {
+		t.Errorf("unexpected stacked maximum: got:%v want:4", ymax)
+	}
+
+	_, err = NewBarGroup([]Valuer{Values{1}, Values{1, 2}}, nil, Grouped)
+	if err == nil {
+		t.Error("expected error for series of different lengths")
+	}
+}
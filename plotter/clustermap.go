@@ -0,0 +1,216 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// ClusteredHeatMap draws a heat map with its rows and columns
+// reordered by hierarchical clustering, and the dendrograms of
+// the clusterings aligned along the left and top margins.
+type ClusteredHeatMap struct {
+	// Plot is the plot holding the heat map. The
+	// title and axes of the plot may be configured
+	// before drawing.
+	Plot *plot.Plot
+
+	// HeatMap is the heat map of the reordered grid.
+	HeatMap *HeatMap
+
+	// Rows and Cols are the dendrograms of the
+	// clusterings of the rows and columns of the
+	// grid. Rows or Cols is nil if the rows or
+	// columns are not clustered.
+	Rows, Cols *Dendrogram
+
+	// DendrogramSize is the width of the margins
+	// holding the dendrograms.
+	DendrogramSize vg.Length
+}
+
+// NewClusteredHeatMap returns a new ClusteredHeatMap of the grid
+// g colored with the palette p. The rows and columns of g are
+// clustered using the distances between rows in rowDist and
+// between columns in colDist with the given linkage. If rowDist
+// or colDist is nil, the rows or columns are left in their
+// original order. The rows and columns of the heat map are
+// labelled with the reordered rowNames and colNames if they
+// are not nil.
+func NewClusteredHeatMap(g GridXYZ, rowDist, colDist Distancer, l Linkage, rowNames, colNames []string, p palette.Palette) (*ClusteredHeatMap, error) {
+	cols, rows := g.Dims()
+	if (rowNames != nil && len(rowNames) != rows) || (colNames != nil && len(colNames) != cols) {
+		return nil, errors.New("Number of names does not match the grid dimensions")
+	}
+
+	h := &ClusteredHeatMap{DendrogramSize: vg.Points(50)}
+	rg := reorderedGrid{GridXYZ: g, cols: identity(cols), rows: identity(rows)}
+	var err error
+	if rowDist != nil {
+		if rowDist.Len() != rows {
+			return nil, errors.New("Number of row distances does not match the grid dimensions")
+		}
+		h.Rows, err = NewDendrogram(rowDist, l)
+		if err != nil {
+			return nil, err
+		}
+		h.Rows.Horizontal = true
+		h.Rows.Inverted = true
+		rg.rows = h.Rows.Order
+	}
+	if colDist != nil {
+		if colDist.Len() != cols {
+			return nil, errors.New("Number of column distances does not match the grid dimensions")
+		}
+		h.Cols, err = NewDendrogram(colDist, l)
+		if err != nil {
+			return nil, err
+		}
+		rg.cols = h.Cols.Order
+	}
+
+	h.Plot, err = plot.New()
+	if err != nil {
+		return nil, err
+	}
+	h.HeatMap = NewHeatMap(rg, p)
+	h.Plot.Add(h.HeatMap)
+	if colNames != nil {
+		h.Plot.NominalX(reorder(colNames, rg.cols)...)
+	}
+	if rowNames != nil {
+		h.Plot.NominalY(reorder(rowNames, rg.rows)...)
+	}
+	h.Plot.X.Padding = 0
+	h.Plot.Y.Padding = 0
+
+	return h, nil
+}
+
+// identity returns the identity permutation of length n.
+func identity(n int) []int {
+	p := make([]int, n)
+	for i := range p {
+		p[i] = i
+	}
+	return p
+}
+
+// reorder returns the names permuted by order.
+func reorder(names []string, order []int) []string {
+	r := make([]string, len(order))
+	for i, o := range order {
+		r[i] = names[o]
+	}
+	return r
+}
+
+// reorderedGrid is a GridXYZ with permuted rows and columns
+// located at unit spacing.
+type reorderedGrid struct {
+	GridXYZ
+	cols, rows []int
+}
+
+func (g reorderedGrid) Z(c, r int) float64 { return g.GridXYZ.Z(g.cols[c], g.rows[r]) }
+func (g reorderedGrid) X(c int) float64 {
+	if c < 0 || c >= len(g.cols) {
+		panic("index out of range")
+	}
+	return float64(c)
+}
+func (g reorderedGrid) Y(r int) float64 {
+	if r < 0 || r >= len(g.rows) {
+		panic("index out of range")
+	}
+	return float64(r)
+}
+
+// Draw draws the clustered heat map to c.
+func (h *ClusteredHeatMap) Draw(c draw.Canvas) {
+	// The title is drawn above the column dendrogram.
+	p := *h.Plot
+	if p.Title.Text != "" {
+		c.FillText(p.Title.TextStyle, vg.Point{X: c.Center().X, Y: c.Max.Y}, p.Title.Text)
+		c.Max.Y -= p.Title.Height(p.Title.Text) - p.Title.Font.Extents().Descent
+		c.Max.Y -= p.Title.Padding
+		p.Title.Text = ""
+	}
+
+	area := c
+	if h.Rows != nil {
+		area.Min.X += h.DendrogramSize
+	}
+	if h.Cols != nil {
+		area.Max.Y -= h.DendrogramSize
+	}
+	p.Draw(area)
+	data := p.DataCanvas(area)
+
+	// gap separates the dendrograms from the heat map.
+	gap := vg.Points(2)
+	if h.Rows != nil {
+		dc := data
+		dc.Min.X, dc.Max.X = c.Min.X, c.Min.X+h.DendrogramSize-gap
+		drawDendrogram(dc, h.Rows, p.Y.Min, p.Y.Max)
+	}
+	if h.Cols != nil {
+		dc := data
+		dc.Min.Y, dc.Max.Y = area.Max.Y+gap, c.Max.Y
+		drawDendrogram(dc, h.Cols, p.X.Min, p.X.Max)
+	}
+}
+
+// drawDendrogram draws d into c with the leaf axis
+// spanning min to max and the height axis spanning
+// the data range of d.
+func drawDendrogram(c draw.Canvas, d *Dendrogram, min, max float64) {
+	xmin, xmax, ymin, ymax := d.DataRange()
+	if d.Horizontal {
+		ymin, ymax = min, max
+	} else {
+		xmin, xmax = min, max
+	}
+	var s plot.LinearScale
+	trX := func(x float64) vg.Length { return c.X(s.Normalize(xmin, xmax, x)) }
+	trY := func(y float64) vg.Length { return c.Y(s.Normalize(ymin, ymax, y)) }
+	d.plot(c, trX, trY)
+}
+
+// Save saves the clustered heat map to an image file. The file
+// format is determined by the extension as for plot.Plot.Save.
+func (h *ClusteredHeatMap) Save(w, ht vg.Length, file string) (err error) {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		e := f.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+
+	format := strings.ToLower(filepath.Ext(file))
+	if len(format) != 0 {
+		format = format[1:]
+	}
+	c, err := draw.NewFormattedCanvas(w, ht, format)
+	if err != nil {
+		return err
+	}
+	h.Draw(draw.New(c))
+
+	_, err = c.WriteTo(f)
+	return err
+}
@@ -20,6 +20,23 @@ func sect(h, p [5]float64, v1, v2 int) float64 {
 	return (h[v2]*p[v1] - h[v1]*p[v2]) / (h[v2] - h[v1])
 }
 
+// box fills xh and yh with the coordinates of the centre and the four
+// corners of the grid box (i, j) to (i+1, j+1) in the vertex order used
+// by conrec, and returns the grid values at the corners. The value for the
+// centre vertex, index 0, is not set.
+func box(g GridXYZ, i, j int, xh, yh *[5]float64) (zh [5]float64) {
+	im := [4]int{0, 1, 1, 0}
+	jm := [4]int{0, 0, 1, 1}
+	for m := 1; m <= 4; m++ {
+		zh[m] = g.Z(i+im[m-1], j+jm[m-1])
+		xh[m] = g.X(i + im[m-1])
+		yh[m] = g.Y(j + jm[m-1])
+	}
+	xh[0] = 0.50 * (g.X(i) + g.X(i+1))
+	yh[0] = 0.50 * (g.Y(j) + g.Y(j+1))
+	return zh
+}
+
 // conrecLine performs an operation with a line at a given height derived
 // from data over the 2D box interval (i, j) to (i+1, j+1).
 type conrecLine func(i, j int, l line, height float64)
@@ -40,9 +57,6 @@ func conrec(g GridXYZ, heights []float64, fn conrecLine) {
 		sh     [5]int
 		xh, yh [5]float64
 
-		im = [4]int{0, 1, 1, 0}
-		jm = [4]int{0, 0, 1, 1}
-
 		// We differ from conrec.c in the assignment of a single value
 		// in cases (castab in conrec.c). The value of castab[1][1][1] is
 		// 3, but we set cases[1][1][1] to 0.
@@ -96,15 +110,12 @@ func conrec(g GridXYZ, heights []float64, fn conrecLine) {
 				if heights[k] < dmin || dmax < heights[k] {
 					continue
 				}
+				zh := box(g, i, j, &xh, &yh)
 				for m := 4; m >= 0; m-- {
 					if m > 0 {
-						h[m] = g.Z(i+im[m-1], j+jm[m-1]) - heights[k]
-						xh[m] = g.X(i + im[m-1])
-						yh[m] = g.Y(j + jm[m-1])
+						h[m] = zh[m] - heights[k]
 					} else {
 						h[0] = 0.25 * (h[1] + h[2] + h[3] + h[4])
-						xh[0] = 0.50 * (g.X(i) + g.X(i+1))
-						yh[0] = 0.50 * (g.Y(j) + g.Y(j+1))
 					}
 					if h[m] > 0 {
 						sh[m] = 1
@@ -43,6 +43,18 @@ type Contour struct {
 	// Min and Max define the dynamic range of the
 	// heat map.
 	Min, Max float64
+
+	// LabelStyle is the style of the inline labels
+	// giving the level of each contour line. Contour
+	// lines are only labelled if the font size of
+	// LabelStyle is not zero. If the color of LabelStyle
+	// is nil, each label is drawn in the color of its line.
+	LabelStyle draw.TextStyle
+
+	// LabelFormat is the fmt package format string
+	// used to format the level in the inline labels.
+	// If it is empty, "%g" is used.
+	LabelFormat string
 }
 
 // NewContour creates as new contour plotter for the given data, using
@@ -150,6 +162,10 @@ func (h *Contour) Plot(c draw.Canvas, plt *plot.Plot) {
 		ps = 0
 	}
 
+	// placed holds the bounds of the inline labels
+	// so that labels do not collide.
+	var placed []vg.Rectangle
+
 	for i, z := range h.Levels {
 		if math.IsNaN(z) {
 			continue
@@ -172,6 +188,9 @@ func (h *Contour) Plot(c draw.Canvas, plt *plot.Plot) {
 				col = pal[int((z-h.Levels[0])*ps+0.5)] // Apply palette scaling.
 			}
 			if col != nil && style.Width != 0 {
+				if h.labelsEnabled() {
+					pa = h.placeLabel(&c, pa, z, col, &placed)
+				}
 				c.SetLineStyle(style)
 				c.SetColor(col)
 				c.Stroke(pa)
@@ -289,6 +308,20 @@ func isLoop(p vg.Path) bool {
 // on the value of the contour level. contouPaths sorts levels ascending as a
 // side effect.
 func contourPaths(m GridXYZ, levels []float64, trX, trY func(float64) vg.Length) map[float64][]vg.Path {
+	conts := contours(m, levels)
+
+	// Build vg.Paths.
+	paths := make(map[float64][]vg.Path)
+	for c := range conts {
+		paths[c.z] = append(paths[c.z], c.path(trX, trY))
+	}
+
+	return paths
+}
+
+// contours returns the set of stitched contours in m cut at the
+// given levels. contours sorts levels ascending as a side effect.
+func contours(m GridXYZ, levels []float64) contourSet {
 	sort.Float64s(levels)
 
 	ends := make(map[float64]endMap)
@@ -315,13 +348,7 @@ func contourPaths(m GridXYZ, levels []float64, trX, trY func(float64) vg.Length)
 		c.exciseLoops(conts, true)
 	}
 
-	// Build vg.Paths.
-	paths := make(map[float64][]vg.Path)
-	for c := range conts {
-		paths[c.z] = append(paths[c.z], c.path(trX, trY))
-	}
-
-	return paths
+	return conts
 }
 
 // contourSet hold a working collection of contours.
@@ -405,19 +432,27 @@ func newContour(l line, z float64) *contour {
 
 func (c *contour) path(trX, trY func(float64) vg.Length) vg.Path {
 	var pa vg.Path
-	p := c.front()
-	pa.Move(vg.Point{X: trX(p.X), Y: trY(p.Y)})
-	for i := len(c.backward) - 2; i >= 0; i-- {
-		p = c.backward[i]
-		pa.Line(vg.Point{X: trX(p.X), Y: trY(p.Y)})
-	}
-	for _, p := range c.forward {
-		pa.Line(vg.Point{X: trX(p.X), Y: trY(p.Y)})
+	for i, p := range c.points() {
+		pt := vg.Point{X: trX(p.X), Y: trY(p.Y)}
+		if i == 0 {
+			pa.Move(pt)
+		} else {
+			pa.Line(pt)
+		}
 	}
 
 	return pa
 }
 
+// points returns the points of the contour in order from front to back.
+func (c *contour) points() path {
+	p := make(path, 0, len(c.backward)+len(c.forward))
+	for i := len(c.backward) - 1; i >= 0; i-- {
+		p = append(p, c.backward[i])
+	}
+	return append(p, c.forward...)
+}
+
 // front returns the first point in the contour.
 func (c *contour) front() point { return c.backward[len(c.backward)-1] }
 
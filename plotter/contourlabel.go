@@ -0,0 +1,220 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// EnableLabels sets the contour lines to be labelled inline
+// with their levels formatted using the given fmt package
+// format string, drawn with the DefaultFont and
+// DefaultFontSize. If format is empty, "%g" is used.
+func (h *Contour) EnableLabels(format string) error {
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return err
+	}
+	if format == "" {
+		format = "%g"
+	}
+	h.LabelFormat = format
+	h.LabelStyle = draw.TextStyle{
+		Font:   fnt,
+		XAlign: draw.XCenter,
+		YAlign: draw.YCenter,
+	}
+	return nil
+}
+
+// labelsEnabled returns whether contour lines are labelled.
+func (h *Contour) labelsEnabled() bool {
+	return h.LabelStyle.Font.Size != 0
+}
+
+// polyline is a path of connected points with the cumulative
+// distance along the path to each point.
+type polyline struct {
+	pts []vg.Point
+	cum []vg.Length
+}
+
+// newPolyline returns the polyline traced by the vg.Path pa,
+// which must consist of a single MoveComp followed by LineComps
+// and an optional CloseComp.
+func newPolyline(pa vg.Path) polyline {
+	var pl polyline
+	for _, comp := range pa {
+		switch comp.Type {
+		case vg.MoveComp, vg.LineComp:
+			pl.add(comp.Pos)
+		case vg.CloseComp:
+			pl.add(pl.pts[0])
+		default:
+			panic("contour: unexpected path component")
+		}
+	}
+	return pl
+}
+
+func (pl *polyline) add(p vg.Point) {
+	var d vg.Length
+	if n := len(pl.pts); n != 0 {
+		d = pl.cum[n-1] + distance(pl.pts[n-1], p)
+	}
+	pl.pts = append(pl.pts, p)
+	pl.cum = append(pl.cum, d)
+}
+
+// length returns the total length of the polyline.
+func (pl polyline) length() vg.Length { return pl.cum[len(pl.cum)-1] }
+
+// at returns the point at distance s along the polyline.
+func (pl polyline) at(s vg.Length) vg.Point {
+	i := sort.Search(len(pl.cum), func(i int) bool { return pl.cum[i] >= s })
+	switch {
+	case i == 0:
+		return pl.pts[0]
+	case i == len(pl.cum):
+		return pl.pts[len(pl.pts)-1]
+	}
+	seg := pl.cum[i] - pl.cum[i-1]
+	if seg == 0 {
+		return pl.pts[i]
+	}
+	t := (s - pl.cum[i-1]) / seg
+	a, b := pl.pts[i-1], pl.pts[i]
+	return vg.Point{X: a.X + t*(b.X-a.X), Y: a.Y + t*(b.Y-a.Y)}
+}
+
+// without returns the path formed by the parts of the
+// polyline before s0 and after s1.
+func (pl polyline) without(s0, s1 vg.Length) vg.Path {
+	var pa vg.Path
+	pa.Move(pl.pts[0])
+	for i := 1; i < len(pl.pts) && pl.cum[i] < s0; i++ {
+		pa.Line(pl.pts[i])
+	}
+	pa.Line(pl.at(s0))
+
+	pa.Move(pl.at(s1))
+	for i, p := range pl.pts {
+		if pl.cum[i] > s1 {
+			pa.Line(p)
+		}
+	}
+	return pa
+}
+
+// labelPlacement is a candidate location for a contour label.
+type labelPlacement struct {
+	start, end vg.Length
+	center     vg.Point
+	rotation   float64
+	score      float64
+}
+
+// placeLabel attempts to place a label for the level z on the
+// contour path pa. The label is placed on the straightest part
+// of the path, preferring the middle of the path, where it does
+// not collide with any of the previously placed label bounds in
+// placed and lies within c. If a label is placed, it is drawn in
+// col and the path with a gap left for the label is returned,
+// otherwise pa is returned.
+func (h *Contour) placeLabel(c *draw.Canvas, pa vg.Path, z float64, col color.Color, placed *[]vg.Rectangle) vg.Path {
+	format := h.LabelFormat
+	if format == "" {
+		format = "%g"
+	}
+	txt := fmt.Sprintf(format, z)
+	sty := h.LabelStyle
+	w, ht := sty.Width(txt), sty.Height(txt)
+
+	// The gap in the line is padded on each side of the text.
+	gap := w + ht/2
+
+	pl := newPolyline(pa)
+	total := pl.length()
+	if total < 2*gap {
+		return pa
+	}
+
+	var candidates []labelPlacement
+	for s := vg.Length(0); s+gap <= total; s += gap / 4 {
+		a, b := pl.at(s), pl.at(s+gap)
+		chord := distance(a, b)
+		d := b.Sub(a)
+		rot := math.Atan2(float64(d.Y), float64(d.X))
+		if rot > math.Pi/2 {
+			rot -= math.Pi
+		} else if rot < -math.Pi/2 {
+			rot += math.Pi
+		}
+		mid := s + gap/2
+		candidates = append(candidates, labelPlacement{
+			start:    s,
+			end:      s + gap,
+			center:   pl.at(mid),
+			rotation: rot,
+			score:    float64(chord/gap) - 0.1*math.Abs(float64((mid-total/2)/total)),
+		})
+	}
+	sort.Stable(byScore(candidates))
+
+	for _, cand := range candidates {
+		// Only consider straight-ish sections of the path.
+		if cand.score < 0.9 {
+			break
+		}
+		bounds := rotatedBounds(cand.center, w, ht, cand.rotation)
+		if !c.Contains(bounds.Min) || !c.Contains(bounds.Max) || overlaps(bounds, *placed) {
+			continue
+		}
+		*placed = append(*placed, bounds)
+
+		sty.Rotation = cand.rotation
+		if sty.Color == nil {
+			sty.Color = col
+		}
+		c.FillText(sty, cand.center, txt)
+		return pl.without(cand.start, cand.end)
+	}
+	return pa
+}
+
+// byScore sorts label placements by descending score.
+type byScore []labelPlacement
+
+func (p byScore) Len() int           { return len(p) }
+func (p byScore) Less(i, j int) bool { return p[i].score > p[j].score }
+func (p byScore) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+// rotatedBounds returns the bounding box of a w×h rectangle
+// centered at center and rotated by rot radians.
+func rotatedBounds(center vg.Point, w, h vg.Length, rot float64) vg.Rectangle {
+	cos, sin := vg.Length(math.Abs(math.Cos(rot))), vg.Length(math.Abs(math.Sin(rot)))
+	hw := (w*cos + h*sin) / 2
+	hh := (w*sin + h*cos) / 2
+	return vg.Rectangle{
+		Min: vg.Point{X: center.X - hw, Y: center.Y - hh},
+		Max: vg.Point{X: center.X + hw, Y: center.Y + hh},
+	}
+}
+
+// overlaps returns whether r overlaps any of the rectangles in rs.
+func overlaps(r vg.Rectangle, rs []vg.Rectangle) bool {
+	for _, o := range rs {
+		if r.Min.X < o.Max.X && o.Min.X < r.Max.X && r.Min.Y < o.Max.Y && o.Min.Y < r.Max.Y {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
+)
+
+// ExampleContour_labels draws contour lines with
+// inline labels giving their levels.
+func ExampleContour_labels() {
+	g := peaks{n: 40}
+	c := NewContour(g, []float64{-4, -2, 0, 2, 4, 6}, palette.Rainbow(6, palette.Blue, palette.Red, 1, 0.8, 1))
+	err := c.EnableLabels("%g")
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Labelled contours"
+	p.Add(c)
+	p.X.Padding = 0
+	p.Y.Padding = 0
+
+	err = p.Save(250, 250, "testdata/contourLabels.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestContourLabels(t *testing.T) {
+	checkPlot(ExampleContour_labels, t, "contourLabels.png")
+}
+
+func TestPolylineWithout(t *testing.T) {
+	var pa vg.Path
+	pa.Move(vg.Point{X: 0, Y: 0})
+	pa.Line(vg.Point{X: 10, Y: 0})
+	pa.Line(vg.Point{X: 10, Y: 10})
+	pl := newPolyline(pa)
+	if pl.length() != 20 {
+		t.Errorf("unexpected length: got:%v want:20", pl.length())
+	}
+
+	var want vg.Path
+	want.Move(vg.Point{X: 0, Y: 0})
+	want.Line(vg.Point{X: 4, Y: 0})
+	want.Move(vg.Point{X: 10, Y: 2})
+	want.Line(vg.Point{X: 10, Y: 10})
+	if got := pl.without(4, 12); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected path with gap:\ngot: %v\nwant:%v", got, want)
+	}
+}
+
+func TestLabelOverlaps(t *testing.T) {
+	r := rotatedBounds(vg.Point{X: 10, Y: 10}, 4, 2, 0)
+	want := vg.Rectangle{Min: vg.Point{X: 8, Y: 9}, Max: vg.Point{X: 12, Y: 11}}
+	if r != want {
+		t.Errorf("unexpected bounds: got:%v want:%v", r, want)
+	}
+	if overlaps(r, []vg.Rectangle{{Min: vg.Point{X: 12, Y: 9}, Max: vg.Point{X: 14, Y: 11}}}) {
+		t.Error("unexpected overlap of touching rectangles")
+	}
+	if !overlaps(r, []vg.Rectangle{{Min: vg.Point{X: 11, Y: 10}, Max: vg.Point{X: 14, Y: 11}}}) {
+		t.Error("expected overlap")
+	}
+}
+
+func TestContourLabelDefaultFormat(t *testing.T) {
+	c := NewContour(peaks{n: 40}, []float64{0, 2}, palette.Heat(2, 1))
+	if err := c.EnableLabels(""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.LabelFormat = ""
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(c)
+
+	var r recorder.Canvas
+	c.Plot(draw.Canvas{
+		Canvas:    &r,
+		Rectangle: vg.Rectangle{Max: vg.Point{X: 250, Y: 250}},
+	}, p)
+	var n int
+	for _, a := range r.Actions {
+		s, ok := a.(*recorder.FillString)
+		if !ok {
+			continue
+		}
+		n++
+		if s.String != "0" && s.String != "2" {
+			t.Errorf("unexpected label: %q", s.String)
+		}
+	}
+	if n == 0 {
+		t.Error("no labels drawn")
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+)
+
+// ContourLine is a single contour line in data coordinates.
+type ContourLine struct {
+	// Level is the height of the contour line.
+	Level float64
+
+	// XYs holds the points along the line.
+	XYs XYs
+
+	// Closed indicates that the line is a closed
+	// loop. The first and last points of a closed
+	// line are equal.
+	Closed bool
+}
+
+// Lines returns the contour lines of the GridXYZ field at each
+// of the Levels of h, in data coordinates. The lines are ordered
+// by ascending level. Lines at the same level are ordered by the
+// location of their first point. Lines sorts the Levels of h
+// ascending as a side effect.
+func (h *Contour) Lines() []ContourLine {
+	var lines []ContourLine
+	for c := range contours(h.GridXYZ, h.Levels) {
+		if math.IsNaN(c.z) {
+			continue
+		}
+		pts := c.points()
+		xys := make(XYs, len(pts))
+		for i, p := range pts {
+			xys[i].X, xys[i].Y = p.X, p.Y
+		}
+		lines = append(lines, ContourLine{
+			Level:  c.z,
+			XYs:    xys,
+			Closed: len(pts) > 1 && pts[0] == pts[len(pts)-1],
+		})
+	}
+	sort.Sort(byLevel(lines))
+	return lines
+}
+
+// byLevel sorts contour lines by level and then by the
+// location of their first point.
+type byLevel []ContourLine
+
+func (l byLevel) Len() int { return len(l) }
+func (l byLevel) Less(i, j int) bool {
+	if l[i].Level != l[j].Level {
+		return l[i].Level < l[j].Level
+	}
+	a, b := l[i].XYs[0], l[j].XYs[0]
+	if a.X != b.X {
+		return a.X < b.X
+	}
+	return a.Y < b.Y
+}
+func (l byLevel) Swap(i, j int) { l[i], l[j] = l[j], l[i] }
+
+// WriteGeoJSON writes the contour lines to w as a GeoJSON
+// FeatureCollection. Open lines are written as LineString
+// features and closed lines as Polygon features with their
+// ring wound anticlockwise. The level of each line is held
+// in the "level" property of its feature.
+//
+// Coordinates are written as given, so the X and Y values of
+// the lines should be longitude and latitude if the output
+// is to conform to RFC 7946.
+func WriteGeoJSON(w io.Writer, lines []ContourLine) error {
+	type geometry struct {
+		Type        string      `json:"type"`
+		Coordinates interface{} `json:"coordinates"`
+	}
+	type feature struct {
+		Type       string             `json:"type"`
+		Geometry   geometry           `json:"geometry"`
+		Properties map[string]float64 `json:"properties"`
+	}
+	fc := struct {
+		Type     string    `json:"type"`
+		Features []feature `json:"features"`
+	}{
+		Type:     "FeatureCollection",
+		Features: make([]feature, 0, len(lines)),
+	}
+
+	for _, l := range lines {
+		if err := CheckFloats(l.Level); err != nil {
+			return err
+		}
+		coords := make([][2]float64, len(l.XYs))
+		var area float64
+		for i, p := range l.XYs {
+			if err := CheckFloats(p.X, p.Y); err != nil {
+				return err
+			}
+			coords[i] = [2]float64{p.X, p.Y}
+			if i > 0 {
+				q := l.XYs[i-1]
+				area += q.X*p.Y - p.X*q.Y
+			}
+		}
+
+		g := geometry{Type: "LineString", Coordinates: coords}
+		if l.Closed {
+			if area < 0 {
+				for i, j := 0, len(coords)-1; i < j; i, j = i+1, j-1 {
+					coords[i], coords[j] = coords[j], coords[i]
+				}
+			}
+			g = geometry{Type: "Polygon", Coordinates: [][][2]float64{coords}}
+		}
+		fc.Features = append(fc.Features, feature{
+			Type:       "Feature",
+			Geometry:   g,
+			Properties: map[string]float64{"level": l.Level},
+		})
+	}
+
+	return json.NewEncoder(w).Encode(fc)
+}
@@ -0,0 +1,106 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+)
+
+func TestContourLines(t *testing.T) {
+	g := unitGrid{mat64.NewDense(3, 3, []float64{
+		0, 1, 2,
+		0, 4, 2,
+		0, 1, 2,
+	})}
+	c := NewContour(g, []float64{3, 0.5}, nil)
+	lines := c.Lines()
+	if len(lines) != 2 {
+		t.Fatalf("unexpected number of lines: got:%d want:2", len(lines))
+	}
+
+	open := lines[0]
+	if open.Level != 0.5 || open.Closed {
+		t.Errorf("unexpected first line: got level:%v closed:%t want level:0.5 closed:false", open.Level, open.Closed)
+	}
+	for _, p := range open.XYs {
+		if p.X < 0 || p.X > 1 {
+			t.Errorf("unexpected point on open line: %v", p)
+		}
+	}
+
+	loop := lines[1]
+	if loop.Level != 3 || !loop.Closed {
+		t.Errorf("unexpected second line: got level:%v closed:%t want level:3 closed:true", loop.Level, loop.Closed)
+	}
+	if first, last := loop.XYs[0], loop.XYs[len(loop.XYs)-1]; first != last {
+		t.Errorf("closed line does not end at its start: %v != %v", first, last)
+	}
+}
+
+func TestWriteGeoJSON(t *testing.T) {
+	lines := []ContourLine{
+		{
+			Level: 1,
+			XYs:   XYs{{0, 0}, {1, 1}},
+		},
+		{
+			Level:  2,
+			XYs:    XYs{{0, 0}, {0, 1}, {1, 1}, {0, 0}},
+			Closed: true,
+		},
+	}
+
+	var buf bytes.Buffer
+	err := WriteGeoJSON(&buf, lines)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var fc struct {
+		Type     string
+		Features []struct {
+			Type     string
+			Geometry struct {
+				Type        string
+				Coordinates json.RawMessage
+			}
+			Properties map[string]float64
+		}
+	}
+	err = json.Unmarshal(buf.Bytes(), &fc)
+	if err != nil {
+		t.Fatalf("failed to decode GeoJSON: %v", err)
+	}
+	if fc.Type != "FeatureCollection" || len(fc.Features) != 2 {
+		t.Fatalf("unexpected feature collection: %s", buf.Bytes())
+	}
+	for i, want := range []struct {
+		typ    string
+		level  float64
+		coords string
+	}{
+		{typ: "LineString", level: 1, coords: "[[0,0],[1,1]]"},
+		// The clockwise ring is reversed.
+		{typ: "Polygon", level: 2, coords: "[[[0,0],[1,1],[0,1],[0,0]]]"},
+	} {
+		f := fc.Features[i]
+		if f.Type != "Feature" || f.Geometry.Type != want.typ || f.Properties["level"] != want.level {
+			t.Errorf("unexpected feature %d: got type:%s geometry:%s level:%v", i, f.Type, f.Geometry.Type, f.Properties["level"])
+		}
+		if string(f.Geometry.Coordinates) != want.coords {
+			t.Errorf("unexpected coordinates for feature %d: got:%s want:%s", i, f.Geometry.Coordinates, want.coords)
+		}
+	}
+
+	err = WriteGeoJSON(&buf, []ContourLine{{Level: 1, XYs: XYs{{0, math.NaN()}}}})
+	if err != ErrNaN {
+		t.Errorf("unexpected error for NaN coordinate: got:%v want:%v", err, ErrNaN)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Decimation specifies how the points of a large series
+// are reduced to the number that can be distinguished on
+// the canvas before drawing. Decimation is computed from
+// the width of the canvas at draw time and assumes that
+// the points are ordered by X; Scatter sorts its points
+// by X before decimating them.
+type Decimation int
+
+const (
+	// NoDecimation draws every point.
+	NoDecimation Decimation = iota
+
+	// LTTB selects about one point per pixel column
+	// using the largest-triangle-three-buckets algorithm,
+	// which preserves the visual shape of the series.
+	LTTB
+
+	// MinMax keeps the first, last, minimum and maximum
+	// points in each pixel column, preserving the
+	// envelope of the series exactly.
+	MinMax
+)
+
+// defaultDPI is the resolution assumed for canvases
+// that do not report their resolution.
+const defaultDPI = 150
+
+// canvasDPI returns the resolution of c in dots per inch,
+// or defaultDPI if c does not report its resolution.
+func canvasDPI(c draw.Canvas) float64 {
+	if d, ok := c.Canvas.(interface {
+		DPI() float64
+	}); ok {
+		return d.DPI()
+	}
+	return defaultDPI
+}
+
+// decimate returns the indices of the points of ps, which are
+// in the coordinates of c, retained by the method d.
+func decimate(c draw.Canvas, ps []vg.Point, d Decimation) []int {
+	if d == NoDecimation {
+		return all(len(ps))
+	}
+	dpi := canvasDPI(c)
+	cols := int(math.Ceil(c.Size().X.Dots(dpi)))
+	if cols <= 0 {
+		return all(len(ps))
+	}
+	switch d {
+	case LTTB:
+		return lttb(ps, cols)
+	case MinMax:
+		// width is the width of a pixel column.
+		width := c.Size().X / vg.Length(cols)
+		return minMax(ps, c.Min.X, width)
+	}
+	panic("plotter: unknown decimation")
+}
+
+// all returns the indices 0 to n-1.
+func all(n int) []int {
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	return idx
+}
+
+// lttb returns the indices of n points selected from ps using
+// the largest-triangle-three-buckets algorithm. The first and
+// last points are always retained. If ps has no more than n
+// points or n is less than 3, all points are retained.
+func lttb(ps []vg.Point, n int) []int {
+	if n < 3 || len(ps) <= n {
+		return all(len(ps))
+	}
+	out := make([]int, 0, n)
+	out = append(out, 0)
+
+	// The inner points are divided into n-2 buckets
+	// and one point is selected from each bucket.
+	size := float64(len(ps)-2) / float64(n-2)
+	a := 0
+	for b := 0; b < n-2; b++ {
+		lo := int(float64(b)*size) + 1
+		hi := int(float64(b+1)*size) + 1
+
+		// The selected point forms the largest triangle with
+		// the previously selected point and the mean of the
+		// next bucket.
+		nlo, nhi := hi, int(float64(b+2)*size)+1
+		if nhi > len(ps)-1 || b == n-3 {
+			nlo, nhi = len(ps)-1, len(ps)
+		}
+		var mean vg.Point
+		for _, p := range ps[nlo:nhi] {
+			mean.X += p.X
+			mean.Y += p.Y
+		}
+		mean.X /= vg.Length(nhi - nlo)
+		mean.Y /= vg.Length(nhi - nlo)
+
+		best, area := lo, -1.0
+		for i := lo; i < hi; i++ {
+			ar := math.Abs(float64((ps[a].X-mean.X)*(ps[i].Y-ps[a].Y) - (ps[a].X-ps[i].X)*(mean.Y-ps[a].Y)))
+			if ar > area {
+				best, area = i, ar
+			}
+		}
+		out = append(out, best)
+		a = best
+	}
+	return append(out, len(ps)-1)
+}
+
+// minMax returns the indices of the first, last, minimum and
+// maximum points of each run of consecutive points of ps falling
+// in the same column of the given width starting at x0. The
+// indices are returned in ascending order.
+func minMax(ps []vg.Point, x0, width vg.Length) []int {
+	if len(ps) <= 4 || width <= 0 {
+		return all(len(ps))
+	}
+	column := func(p vg.Point) int {
+		return int(math.Floor(float64((p.X - x0) / width)))
+	}
+	var out []int
+	for start := 0; start < len(ps); {
+		col := column(ps[start])
+		end, lo, hi := start+1, start, start
+		for ; end < len(ps) && column(ps[end]) == col; end++ {
+			if ps[end].Y < ps[lo].Y {
+				lo = end
+			}
+			if ps[end].Y > ps[hi].Y {
+				hi = end
+			}
+		}
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		for _, i := range []int{start, lo, hi, end - 1} {
+			if n := len(out); n == 0 || out[n-1] != i {
+				out = append(out, i)
+			}
+		}
+		start = end
+	}
+	return out
+}
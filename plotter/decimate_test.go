@@ -0,0 +1,90 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// ExampleLine_decimated draws a long noisy series reduced
+// to its per-pixel envelope.
+func ExampleLine_decimated() {
+	rnd := rand.New(rand.NewSource(1))
+
+	n := 200000
+	pts := make(XYs, n)
+	for i := range pts {
+		x := float64(i) / float64(n)
+		pts[i].X = x
+		pts[i].Y = math.Sin(4*math.Pi*x) + 0.2*rnd.NormFloat64()
+		if i%50000 == 25000 {
+			// Add some spikes that must survive decimation.
+			pts[i].Y += 2
+		}
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Decimated line"
+
+	l, err := NewLine(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	l.Decimation = MinMax
+	p.Add(l)
+
+	err = p.Save(200, 200, "testdata/lineDecimated.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestLineDecimated(t *testing.T) {
+	checkPlot(ExampleLine_decimated, t, "lineDecimated.png")
+}
+
+func TestLTTB(t *testing.T) {
+	ps := make([]vg.Point, 11)
+	for i := range ps {
+		ps[i].X = vg.Length(i)
+	}
+	// A single spike in each bucket must be selected.
+	ps[2].Y = 5
+	ps[5].Y = -5
+	ps[8].Y = 3
+
+	got := lttb(ps, 5)
+	want := []int{0, 2, 5, 8, 10}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected LTTB selection: got:%v want:%v", got, want)
+	}
+
+	if got := lttb(ps, 20); len(got) != len(ps) {
+		t.Errorf("unexpected decimation of short series: got %d points want %d", len(got), len(ps))
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	ps := []vg.Point{
+		{0, 1}, {0.2, 3}, {0.5, -2}, {0.9, 0},
+		{1.1, 4},
+		{2.0, 1}, {2.5, 1}, {2.7, 0}, {2.9, 2}, {2.95, 1},
+	}
+	got := minMax(ps, 0, 1)
+	want := []int{0, 1, 2, 3, 4, 5, 7, 8, 9}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected min-max selection: got:%v want:%v", got, want)
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Distancer wraps the Len and Distance methods.
+type Distancer interface {
+	// Len returns the number of items.
+	Len() int
+
+	// Distance returns the distance between
+	// the ith and jth items.
+	Distance(i, j int) float64
+}
+
+// Linkage specifies how the distance between
+// clusters of items is computed.
+type Linkage int
+
+const (
+	// SingleLinkage uses the smallest distance between
+	// the items of two clusters.
+	SingleLinkage Linkage = iota
+
+	// CompleteLinkage uses the largest distance between
+	// the items of two clusters.
+	CompleteLinkage
+
+	// AverageLinkage uses the mean distance between
+	// the items of two clusters.
+	AverageLinkage
+
+	// WardLinkage merges the clusters that give the
+	// smallest increase in the within-cluster variance.
+	// The distances should be Euclidean.
+	WardLinkage
+)
+
+// Merge is a single merge of two clusters in a hierarchical
+// clustering of n items. Clusters holding a single item are
+// numbered by the index of the item, and the cluster formed
+// by the kth merge is numbered n+k.
+type Merge struct {
+	// A and B are the clusters merged.
+	A, B int
+
+	// Height is the distance between the
+	// clusters when they were merged.
+	Height float64
+}
+
+// Dendrogram implements the Plotter interface, drawing the
+// tree of merges of an agglomerative hierarchical clustering.
+// The leaves of the tree are placed at integer locations along
+// the X axis, and the merges at their heights along the Y axis.
+type Dendrogram struct {
+	// Merges holds the merges of the clustering in
+	// order of increasing height.
+	Merges []Merge
+
+	// Order holds the indices of the items in the order
+	// of the leaves of the tree, so the ith leaf is item
+	// Order[i] and is located at i.
+	Order []int
+
+	// LineStyle is the style of the tree lines.
+	LineStyle draw.LineStyle
+
+	// Horizontal dictates whether the leaves are placed along
+	// the X axis (default) or the Y axis. If Horizontal is true,
+	// the heights of the merges are along the X axis.
+	Horizontal bool
+
+	// Inverted specifies that the heights of the merges
+	// increase in the negative direction, for example to
+	// place a horizontal dendrogram to the left of a plot.
+	Inverted bool
+}
+
+// NewDendrogram returns a new Dendrogram of the hierarchical
+// clustering of the items described by d using the given
+// linkage.
+func NewDendrogram(d Distancer, l Linkage) (*Dendrogram, error) {
+	merges, order, err := cluster(d, l)
+	if err != nil {
+		return nil, err
+	}
+	return &Dendrogram{
+		Merges:    merges,
+		Order:     order,
+		LineStyle: DefaultLineStyle,
+	}, nil
+}
+
+// cluster performs agglomerative hierarchical clustering of the
+// items in d, returning the merges and the order of the leaves.
+// Distances between clusters are updated using the Lance-Williams
+// formulae. Ties are broken in favor of the lowest item indices.
+func cluster(d Distancer, l Linkage) ([]Merge, []int, error) {
+	n := d.Len()
+	if n == 0 {
+		return nil, nil, ErrNoData
+	}
+	if l < SingleLinkage || l > WardLinkage {
+		return nil, nil, errors.New("Unknown linkage")
+	}
+	dist := make([][]float64, n)
+	for i := range dist {
+		dist[i] = make([]float64, n)
+		for j := range dist[i] {
+			v := d.Distance(i, j)
+			if err := CheckFloats(v); err != nil {
+				return nil, nil, err
+			}
+			if v < 0 {
+				return nil, nil, errors.New("Negative distance")
+			}
+			dist[i][j] = v
+		}
+	}
+
+	id := make([]int, n)
+	size := make([]float64, n)
+	active := make([]bool, n)
+	for i := range id {
+		id[i] = i
+		size[i] = 1
+		active[i] = true
+	}
+
+	merges := make([]Merge, 0, n-1)
+	for step := 0; step < n-1; step++ {
+		a, b := -1, -1
+		min := math.Inf(1)
+		for i := 0; i < n; i++ {
+			if !active[i] {
+				continue
+			}
+			for j := i + 1; j < n; j++ {
+				if active[j] && dist[i][j] < min {
+					a, b, min = i, j, dist[i][j]
+				}
+			}
+		}
+		merges = append(merges, Merge{A: id[a], B: id[b], Height: min})
+
+		for k := 0; k < n; k++ {
+			if !active[k] || k == a || k == b {
+				continue
+			}
+			dka, dkb := dist[k][a], dist[k][b]
+			var v float64
+			switch l {
+			case SingleLinkage:
+				v = math.Min(dka, dkb)
+			case CompleteLinkage:
+				v = math.Max(dka, dkb)
+			case AverageLinkage:
+				v = (size[a]*dka + size[b]*dkb) / (size[a] + size[b])
+			case WardLinkage:
+				t := size[a] + size[b] + size[k]
+				v = math.Sqrt(math.Max(0, ((size[a]+size[k])*dka*dka+
+					(size[b]+size[k])*dkb*dkb-
+					size[k]*min*min)/t))
+			}
+			dist[k][a], dist[a][k] = v, v
+		}
+		size[a] += size[b]
+		id[a] = n + step
+		active[b] = false
+	}
+
+	order := make([]int, 0, n)
+	var visit func(c int)
+	visit = func(c int) {
+		if c < n {
+			order = append(order, c)
+			return
+		}
+		visit(merges[c-n].A)
+		visit(merges[c-n].B)
+	}
+	visit(2*n - 2)
+
+	return merges, order, nil
+}
+
+// Plot implements the plot.Plotter interface.
+func (d *Dendrogram) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	d.plot(c, trX, trY)
+}
+
+// plot draws the Dendrogram using the given
+// transforms from data to canvas coordinates.
+func (d *Dendrogram) plot(c draw.Canvas, trX, trY func(float64) vg.Length) {
+	tr := func(cat, val float64) vg.Point {
+		if d.Inverted {
+			val = -val
+		}
+		if d.Horizontal {
+			return vg.Point{X: trX(val), Y: trY(cat)}
+		}
+		return vg.Point{X: trX(cat), Y: trY(val)}
+	}
+
+	n := len(d.Order)
+	pos := make([]float64, n+len(d.Merges))
+	height := make([]float64, len(pos))
+	for i, item := range d.Order {
+		pos[item] = float64(i)
+	}
+	for k, m := range d.Merges {
+		pos[n+k] = (pos[m.A] + pos[m.B]) / 2
+		height[n+k] = m.Height
+		line := []vg.Point{
+			tr(pos[m.A], height[m.A]),
+			tr(pos[m.A], m.Height),
+			tr(pos[m.B], m.Height),
+			tr(pos[m.B], height[m.B]),
+		}
+		c.StrokeLines(d.LineStyle, c.ClipLinesXY(line)...)
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (d *Dendrogram) DataRange() (xmin, xmax, ymin, ymax float64) {
+	catMin, catMax := 0.0, float64(len(d.Order)-1)
+	valMin, valMax := 0.0, 0.0
+	for _, m := range d.Merges {
+		valMax = math.Max(valMax, m.Height)
+	}
+	if d.Inverted {
+		valMin, valMax = -valMax, -valMin
+	}
+	if d.Horizontal {
+		return valMin, valMax, catMin, catMax
+	}
+	return catMin, catMax, valMin, valMax
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (d *Dendrogram) Thumbnail(c *draw.Canvas) {
+	x0, x1 := c.Min.X, c.Max.X
+	c.StrokeLines(d.LineStyle, []vg.Point{
+		{x0, c.Min.Y}, {x0, c.Max.Y}, {x1, c.Max.Y}, {x1, c.Min.Y},
+	})
+}
@@ -0,0 +1,164 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+)
+
+// euclidean is a Distancer of the Euclidean distances
+// between the rows of a matrix.
+type euclidean struct {
+	mat64.Matrix
+}
+
+func (e euclidean) Len() int { r, _ := e.Dims(); return r }
+func (e euclidean) Distance(i, j int) float64 {
+	_, c := e.Dims()
+	var d float64
+	for k := 0; k < c; k++ {
+		d += sq(e.At(i, k) - e.At(j, k))
+	}
+	return math.Sqrt(d)
+}
+
+// transposed is a Distancer of the Euclidean distances
+// between the columns of a matrix.
+type transposed struct {
+	mat64.Matrix
+}
+
+func (t transposed) Len() int { _, c := t.Dims(); return c }
+func (t transposed) Distance(i, j int) float64 {
+	r, _ := t.Dims()
+	var d float64
+	for k := 0; k < r; k++ {
+		d += sq(t.At(k, i) - t.At(k, j))
+	}
+	return math.Sqrt(d)
+}
+
+// points1D is a Distancer of points on a line.
+type points1D []float64
+
+func (p points1D) Len() int                  { return len(p) }
+func (p points1D) Distance(i, j int) float64 { return math.Abs(p[i] - p[j]) }
+
+func ExampleDendrogram() {
+	d, err := NewDendrogram(points1D{0, 9, 1, 5, 10, 2.5, 6}, AverageLinkage)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Dendrogram"
+	p.Y.Label.Text = "Height"
+	p.Add(d)
+
+	names := make([]string, len(d.Order))
+	for i, item := range d.Order {
+		names[i] = string('A' + rune(item))
+	}
+	p.NominalX(names...)
+
+	err = p.Save(200, 200, "testdata/dendrogram.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestDendrogram(t *testing.T) {
+	checkPlot(ExampleDendrogram, t, "dendrogram.png")
+}
+
+func ExampleClusteredHeatMap() {
+	m := mat64.NewDense(5, 6, []float64{
+		1, 8, 2, 9, 1, 8,
+		7, 2, 8, 1, 7, 3,
+		2, 9, 1, 8, 2, 9,
+		8, 1, 7, 2, 9, 2,
+		5, 5, 4, 6, 5, 4,
+	})
+	h, err := NewClusteredHeatMap(
+		unitGrid{m},
+		euclidean{m}, transposed{m},
+		CompleteLinkage,
+		[]string{"a", "b", "c", "d", "e"},
+		[]string{"P", "Q", "R", "S", "T", "U"},
+		palette.Heat(12, 1),
+	)
+	if err != nil {
+		log.Panic(err)
+	}
+	h.Plot.Title.Text = "Clustered heat map"
+
+	err = h.Save(250, 250, "testdata/clusteredHeatMap.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestClusteredHeatMap(t *testing.T) {
+	checkPlot(ExampleClusteredHeatMap, t, "clusteredHeatMap.png")
+}
+
+func TestCluster(t *testing.T) {
+	pts := points1D{0, 10, 1, 4, 11}
+	for _, test := range []struct {
+		linkage Linkage
+		merges  []Merge
+		order   []int
+	}{
+		{
+			linkage: SingleLinkage,
+			merges:  []Merge{{0, 2, 1}, {1, 4, 1}, {5, 3, 3}, {7, 6, 6}},
+			order:   []int{0, 2, 3, 1, 4},
+		},
+		{
+			linkage: CompleteLinkage,
+			merges:  []Merge{{0, 2, 1}, {1, 4, 1}, {5, 3, 4}, {7, 6, 11}},
+			order:   []int{0, 2, 3, 1, 4},
+		},
+		{
+			linkage: AverageLinkage,
+			merges:  []Merge{{0, 2, 1}, {1, 4, 1}, {5, 3, 3.5}, {7, 6, 53.0 / 6}},
+			order:   []int{0, 2, 3, 1, 4},
+		},
+		{
+			linkage: WardLinkage,
+			merges:  []Merge{{0, 2, 1}, {1, 4, 1}, {5, 3, math.Sqrt(49.0 / 3)}, {7, 6, 53 / math.Sqrt(15)}},
+			order:   []int{0, 2, 3, 1, 4},
+		},
+	} {
+		merges, order, err := cluster(pts, test.linkage)
+		if err != nil {
+			t.Errorf("unexpected error for linkage %d: %v", test.linkage, err)
+			continue
+		}
+		if !reflect.DeepEqual(order, test.order) {
+			t.Errorf("unexpected order for linkage %d: got:%v want:%v", test.linkage, order, test.order)
+		}
+		for i, want := range test.merges {
+			got := merges[i]
+			if got.A != want.A || got.B != want.B || math.Abs(got.Height-want.Height) > 1e-12 {
+				t.Errorf("unexpected merge %d for linkage %d: got:%v want:%v", i, test.linkage, got, want)
+			}
+		}
+	}
+
+	if _, _, err := cluster(points1D{0, math.NaN()}, SingleLinkage); err == nil {
+		t.Error("expected error for NaN distance")
+	}
+}
@@ -5,6 +5,7 @@
 package plotter
 
 import (
+	"errors"
 	"math"
 
 	"github.com/gonum/plot"
@@ -32,6 +33,11 @@ type YErrorBars struct {
 	CapWidth vg.Length
 }
 
+// Len returns the number of points.
+func (e *YErrorBars) Len() int {
+	return len(e.XYs)
+}
+
 // NewYErrorBars returns a new YErrorBars plotter, or an error on failure.
 // The error values from the YErrorer interface are interpreted as relative
 // to the corresponding Y value. The errors for a given Y value are computed
@@ -230,3 +236,43 @@ func (e *XErrorBars) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
 	}
 	return bs
 }
+
+// copyYErrors returns a copy of the Y errors
+// of e, which must hold n errors.
+func copyYErrors(e interface {
+	YErrorer
+	Len() int
+}, n int) (YErrors, error) {
+	if e.Len() != n {
+		return nil, errors.New("Number of errors does not match the number of values")
+	}
+	errs := make(YErrors, n)
+	for i := range errs {
+		errs[i].Low, errs[i].High = e.YError(i)
+		if err := CheckFloats(errs[i].Low, errs[i].High); err != nil {
+			return nil, err
+		}
+	}
+	return errs, nil
+}
+
+// drawErrorBar draws an error bar from lo to hi at the position
+// at across the bar, with caps of width capWidth at its ends. If
+// horizontal is true, the bar is drawn along the X axis.
+func drawErrorBar(c *draw.Canvas, sty draw.LineStyle, capWidth, at, lo, hi vg.Length, horizontal bool) {
+	if !horizontal {
+		c.StrokeLines(sty, c.ClipLinesY([]vg.Point{{at, lo}, {at, hi}})...)
+		for _, y := range []vg.Length{lo, hi} {
+			if c.Contains(vg.Point{X: at, Y: y}) {
+				c.StrokeLine2(sty, at-capWidth/2, y, at+capWidth/2, y)
+			}
+		}
+		return
+	}
+	c.StrokeLines(sty, c.ClipLinesX([]vg.Point{{lo, at}, {hi, at}})...)
+	for _, x := range []vg.Length{lo, hi} {
+		if c.Contains(vg.Point{X: x, Y: at}) {
+			c.StrokeLine2(sty, x, at-capWidth/2, x, at+capWidth/2)
+		}
+	}
+}
@@ -13,6 +13,16 @@ import (
 	"github.com/gonum/plot/vg/draw"
 )
 
+// errPoints holds points with X and Y errors.
+type errPoints struct {
+	XYs
+	YErrors
+	XErrors
+}
+
+// Len returns the number of points.
+func (e errPoints) Len() int { return len(e.XYs) }
+
 // ExampleErrors draws points and error bars.
 func ExampleErrors() {
 	rnd := rand.New(rand.NewSource(1))
@@ -40,12 +50,6 @@ func ExampleErrors() {
 		return pts
 	}
 
-	type errPoints struct {
-		XYs
-		YErrors
-		XErrors
-	}
-
 	n := 15
 	data := errPoints{
 		XYs:     randomPoints(n),
@@ -0,0 +1,350 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// FilledContour implements the Plotter interface, drawing
+// a filled contour plot of the values in the GridXYZ field.
+// Each band between consecutive levels is filled with a
+// palette color.
+//
+// The grid is divided into triangles in the same way as for
+// Contour, so the band boundaries agree with the lines drawn
+// by a Contour with the same levels.
+type FilledContour struct {
+	GridXYZ GridXYZ
+
+	// Levels describes the boundaries of the bands.
+	// Levels is sorted ascending by Plot.
+	Levels []float64
+
+	// Palette is the color palette used to fill the
+	// bands between levels. The palette is scaled
+	// uniformly across the bands. Palette must not
+	// be nil or return a zero length []color.Color.
+	Palette palette.Palette
+
+	// Underflow and Overflow are colors used to fill
+	// the bands below the lowest level and above the
+	// highest level respectively. The bands are not
+	// filled if the color is nil.
+	Underflow color.Color
+	Overflow  color.Color
+}
+
+// NewFilledContour creates a new filled contour plotter for the
+// given data, using the provided palette. If levels is nil, the
+// levels are chosen in the same way as for NewContour.
+func NewFilledContour(g GridXYZ, levels []float64, p palette.Palette) *FilledContour {
+	if len(levels) == 0 {
+		levels = quantilesR7(g, defaultQuantiles)
+	}
+	return &FilledContour{
+		GridXYZ: g,
+		Levels:  levels,
+		Palette: p,
+	}
+}
+
+// bandColors returns the fill colors of the bands of f from the
+// underflow band to the overflow band.
+func (f *FilledContour) bandColors() []color.Color {
+	pal := f.Palette.Colors()
+	if len(pal) == 0 {
+		panic("contour: empty palette")
+	}
+
+	n := len(f.Levels) - 1
+	cols := make([]color.Color, n+2)
+	cols[0] = f.Underflow
+	cols[n+1] = f.Overflow
+
+	// ps is a palette scaling factor to scale the
+	// palette uniformly across the inner bands.
+	var ps float64
+	if n > 1 {
+		ps = float64(len(pal)-1) / float64(n-1)
+	}
+	for i := 0; i < n; i++ {
+		cols[i+1] = pal[int(float64(i)*ps+0.5)]
+	}
+	return cols
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (f *FilledContour) Plot(c draw.Canvas, plt *plot.Plot) {
+	sort.Float64s(f.Levels)
+	cols := f.bandColors()
+	trX, trY := plt.Transforms(&c)
+
+	bounds := make([]float64, 0, len(f.Levels)+2)
+	bounds = append(bounds, math.Inf(-1))
+	bounds = append(bounds, f.Levels...)
+	bounds = append(bounds, math.Inf(1))
+
+	for i, col := range cols {
+		if col == nil {
+			continue
+		}
+		var pa vg.Path
+		for _, loop := range bandLoops(f.GridXYZ, bounds[i], bounds[i+1]) {
+			ct := contour{backward: loop[:1:1], forward: loop[1:]}
+			pa = append(pa, ct.path(trX, trY)...)
+			pa.Close()
+		}
+		if len(pa) == 0 {
+			continue
+		}
+		c.SetColor(col)
+		c.Fill(pa)
+	}
+}
+
+// vertex is a point with an associated grid value.
+type vertex struct {
+	point
+	z float64
+}
+
+// edge is a directed polygon edge.
+type edge struct {
+	from, to point
+}
+
+// bandLoops returns the boundaries of the regions of the grid g
+// with values between lo and hi. The boundaries are returned as
+// closed paths that wind anticlockwise around filled regions and
+// clockwise around holes, so the regions are correctly filled
+// under the non-zero winding rule.
+//
+// Each grid box is divided into four triangles in the same way
+// as by conrec and the value is interpolated linearly over each
+// triangle. Each triangle is clipped to the band and the edges
+// shared by neighbouring clipped triangles are cancelled, leaving
+// only the boundary of the band.
+func bandLoops(g GridXYZ, lo, hi float64) []path {
+	var (
+		xh, yh [5]float64
+		order  []edge
+	)
+	count := make(map[edge]int)
+	add := func(e edge) {
+		if e.from == e.to {
+			return
+		}
+		rev := edge{from: e.to, to: e.from}
+		if count[rev] > 0 {
+			count[rev]--
+			return
+		}
+		if count[e] == 0 {
+			order = append(order, e)
+		}
+		count[e]++
+	}
+
+	c, r := g.Dims()
+	for i := 0; i < c-1; i++ {
+		for j := 0; j < r-1; j++ {
+			zh := box(g, i, j, &xh, &yh)
+			zh[0] = 0.25 * (zh[1] + zh[2] + zh[3] + zh[4])
+			if math.IsNaN(zh[0]) {
+				continue
+			}
+			for m := 1; m <= 4; m++ {
+				m3 := m%4 + 1
+				tri := []vertex{
+					{point{xh[m], yh[m]}, zh[m]},
+					{point{xh[0], yh[0]}, zh[0]},
+					{point{xh[m3], yh[m3]}, zh[m3]},
+				}
+				poly := clipBand(tri, lo, hi)
+				if len(poly) < 3 {
+					continue
+				}
+				if signedArea(poly) < 0 {
+					for a, b := 0, len(poly)-1; a < b; a, b = a+1, b-1 {
+						poly[a], poly[b] = poly[b], poly[a]
+					}
+				}
+				for k := range poly {
+					add(edge{from: poly[k].point, to: poly[(k+1)%len(poly)].point})
+				}
+			}
+		}
+	}
+
+	// Join the remaining edges into loops. Where loops touch
+	// at a vertex any pairing of the edges leaves the winding
+	// numbers of the enclosed regions unchanged.
+	next := make(map[point][]point)
+	for _, e := range order {
+		for n := count[e]; n > 0; n-- {
+			next[e.from] = append(next[e.from], e.to)
+		}
+	}
+	var loops []path
+	for _, e := range order {
+		for len(next[e.from]) != 0 {
+			start := e.from
+			loop := path{start}
+			p := start
+			for {
+				succ := next[p]
+				if len(succ) == 0 {
+					// The boundary is broken; should not happen.
+					break
+				}
+				q := succ[len(succ)-1]
+				next[p] = succ[:len(succ)-1]
+				loop = append(loop, q)
+				if q == start {
+					break
+				}
+				p = q
+			}
+			if len(loop) > 3 {
+				loops = append(loops, loop)
+			}
+		}
+	}
+	return loops
+}
+
+// clipBand returns the polygon formed by clipping the polygon
+// poly to the region where the linearly interpolated value is
+// between lo and hi.
+func clipBand(poly []vertex, lo, hi float64) []vertex {
+	poly = clipLevel(poly, lo, 1)
+	return clipLevel(poly, hi, -1)
+}
+
+// clipLevel returns the part of poly where the interpolated value
+// is at least z if sign is positive, or at most z if sign is
+// negative.
+func clipLevel(poly []vertex, z, sign float64) []vertex {
+	if math.IsInf(z, 0) {
+		return poly
+	}
+	inside := func(v vertex) bool { return sign*(v.z-z) >= 0 }
+	var out []vertex
+	for k, cur := range poly {
+		prev := poly[(k+len(poly)-1)%len(poly)]
+		switch {
+		case inside(cur) && !inside(prev):
+			out = append(out, crossing(prev, cur, z), cur)
+		case inside(cur):
+			out = append(out, cur)
+		case inside(prev):
+			out = append(out, crossing(prev, cur, z))
+		}
+	}
+	return out
+}
+
+// crossing returns the vertex at which the value interpolated
+// along the edge between a and b equals z. The result does not
+// depend on the direction of the edge, so crossings computed for
+// an edge shared by two triangles are identical.
+func crossing(a, b vertex, z float64) vertex {
+	if b.X < a.X || (b.X == a.X && b.Y < a.Y) {
+		a, b = b, a
+	}
+	t := (z - a.z) / (b.z - a.z)
+	return vertex{
+		point: point{X: a.X + t*(b.X-a.X), Y: a.Y + t*(b.Y-a.Y)},
+		z:     z,
+	}
+}
+
+// signedArea returns the signed area of poly, which is
+// positive if the vertices are ordered anticlockwise.
+func signedArea(poly []vertex) float64 {
+	var a float64
+	for k, p := range poly {
+		q := poly[(k+1)%len(poly)]
+		a += p.X*q.Y - q.X*p.Y
+	}
+	return a / 2
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (f *FilledContour) DataRange() (xmin, xmax, ymin, ymax float64) {
+	c, r := f.GridXYZ.Dims()
+	return f.GridXYZ.X(0), f.GridXYZ.X(c - 1), f.GridXYZ.Y(0), f.GridXYZ.Y(r - 1)
+}
+
+// Thumbnail implements the plot.Thumbnailer interface,
+// filling the thumbnail with the color of the middle band.
+func (f *FilledContour) Thumbnail(c *draw.Canvas) {
+	cols := f.bandColors()
+	fillThumbnail(c, cols[len(cols)/2])
+}
+
+// Thumbnailers returns a label and a plot.Thumbnailer for
+// each filled band of f, from the lowest band to the highest,
+// for adding to a plot legend. The levels in the labels are
+// formatted using the given fmt package format string. If
+// format is empty, "%g" is used.
+func (f *FilledContour) Thumbnailers(format string) (labels []string, thumbs []plot.Thumbnailer) {
+	if format == "" {
+		format = "%g"
+	}
+	sort.Float64s(f.Levels)
+	n := len(f.Levels)
+	for i, col := range f.bandColors() {
+		if col == nil {
+			continue
+		}
+		var label string
+		switch i {
+		case 0:
+			label = "< " + fmt.Sprintf(format, f.Levels[0])
+		case n:
+			label = "> " + fmt.Sprintf(format, f.Levels[n-1])
+		default:
+			label = fmt.Sprintf(format, f.Levels[i-1]) + " – " + fmt.Sprintf(format, f.Levels[i])
+		}
+		labels = append(labels, label)
+		thumbs = append(thumbs, bandThumbnailer{col})
+	}
+	return labels, thumbs
+}
+
+// bandThumbnailer is a plot.Thumbnailer for a single band
+// of a FilledContour.
+type bandThumbnailer struct {
+	color.Color
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (b bandThumbnailer) Thumbnail(c *draw.Canvas) {
+	fillThumbnail(c, b.Color)
+}
+
+// fillThumbnail fills the canvas c with col.
+func fillThumbnail(c *draw.Canvas, col color.Color) {
+	if col == nil {
+		return
+	}
+	c.FillPolygon(col, []vg.Point{
+		{c.Min.X, c.Min.Y},
+		{c.Max.X, c.Min.Y},
+		{c.Max.X, c.Max.Y},
+		{c.Min.X, c.Max.Y},
+	})
+}
@@ -0,0 +1,125 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/matrix/mat64"
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+)
+
+// peaks is a GridXYZ holding a surface with two
+// peaks and a pit over a square region.
+type peaks struct{ n int }
+
+func (g peaks) Dims() (c, r int) { return g.n, g.n }
+func (g peaks) X(c int) float64  { return -3 + 6*float64(c)/float64(g.n-1) }
+func (g peaks) Y(r int) float64  { return -3 + 6*float64(r)/float64(g.n-1) }
+func (g peaks) Z(c, r int) float64 {
+	x, y := g.X(c), g.Y(r)
+	return 3*sq(1-x)*math.Exp(-sq(x)-sq(y+1)) -
+		10*(x/5-x*x*x-math.Pow(y, 5))*math.Exp(-sq(x)-sq(y)) -
+		math.Exp(-sq(x+1)-sq(y))/3
+}
+
+// ExampleFilledContour draws filled contours of a surface
+// with the band boundaries outlined by a Contour and a
+// legend entry for each band.
+func ExampleFilledContour() {
+	g := peaks{n: 40}
+	levels := []float64{-4, -2, 0, 2, 4}
+
+	f := NewFilledContour(g, levels, palette.Rainbow(4, palette.Blue, palette.Red, 1, 1, 1))
+	f.Underflow = color.RGBA{B: 128, A: 255}
+	f.Overflow = color.RGBA{R: 128, A: 255}
+
+	c := NewContour(g, levels, nil)
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Filled contour"
+	p.Add(f, c)
+	p.X.Padding = 0
+	p.Y.Padding = 0
+
+	labels, thumbs := f.Thumbnailers("%g")
+	for i := len(labels) - 1; i >= 0; i-- {
+		p.Legend.Add(labels[i], thumbs[i])
+	}
+	p.Legend.Top = true
+	p.Legend.XOffs = -5
+
+	err = p.Save(300, 250, "testdata/filledContour.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestFilledContour(t *testing.T) {
+	checkPlot(ExampleFilledContour, t, "filledContour.png")
+}
+
+func loopsArea(loops []path) float64 {
+	var area float64
+	for _, l := range loops {
+		poly := make([]vertex, len(l)-1)
+		for i := range poly {
+			poly[i].point = l[i]
+		}
+		area += signedArea(poly)
+	}
+	return area
+}
+
+func TestBandLoopsArea(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	data := make([]float64, 12*15)
+	for i := range data {
+		data[i] = rnd.NormFloat64()
+	}
+	// Put some grid values exactly on a level.
+	data[20], data[21], data[40] = 0, 0, 0
+	g := unitGrid{mat64.NewDense(12, 15, data)}
+
+	bounds := []float64{math.Inf(-1), -1, -0.5, 0, 0.5, 1, math.Inf(1)}
+	var total float64
+	for i := 1; i < len(bounds); i++ {
+		area := loopsArea(bandLoops(g, bounds[i-1], bounds[i]))
+		if area < 0 {
+			t.Errorf("unexpected negative area for band %d: %v", i-1, area)
+		}
+		total += area
+	}
+	if want := float64(11 * 14); math.Abs(total-want) > 1e-9 {
+		t.Errorf("unexpected total band area: got:%v want:%v", total, want)
+	}
+}
+
+func TestBandLoopsHole(t *testing.T) {
+	g := unitGrid{mat64.NewDense(3, 3, []float64{
+		0, 0, 0,
+		0, 4, 0,
+		0, 0, 0,
+	})}
+	loops := bandLoops(g, math.Inf(-1), 2)
+	if len(loops) != 2 {
+		t.Fatalf("unexpected number of loops: got:%d want:2", len(loops))
+	}
+	a0, a1 := loopsArea(loops[:1]), loopsArea(loops[1:])
+	if a0*a1 >= 0 {
+		t.Errorf("expected outer boundary and hole with opposite winding: got areas %v and %v", a0, a1)
+	}
+	if got := a0 + a1; got <= 0 || got >= 4 {
+		t.Errorf("unexpected band area: got:%v want in (0,4)", got)
+	}
+}
@@ -0,0 +1,783 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// FitModel is the model of a Fit.
+type FitModel int
+
+const (
+	// PolynomialFit is a least-squares polynomial
+	// fit, y = c₀ + c₁x + c₂x² + ….
+	PolynomialFit FitModel = iota
+
+	// LoessFit is a locally weighted linear
+	// regression smoother.
+	LoessFit
+
+	// ExponentialFit is a fit of y = c₀·exp(c₁x),
+	// found by least squares on ln y.
+	ExponentialFit
+
+	// LogarithmicFit is a least-squares fit
+	// of y = c₀ + c₁·ln x.
+	LogarithmicFit
+)
+
+// Fit implements the Plotter interface, drawing a
+// curve fitted to a set of points, with an optional
+// confidence band and annotation giving the fitted
+// equation.
+type Fit struct {
+	// XYs is a copy of the points that were fitted.
+	XYs
+
+	// Model is the model that was fitted.
+	Model FitModel
+
+	// Coeffs holds the fitted coefficients of the model,
+	// as described for each FitModel. Coeffs is nil for
+	// a LoessFit.
+	Coeffs []float64
+
+	// RSquared is the coefficient of determination of
+	// the fit, computed on the original Y values.
+	RSquared float64
+
+	// LineStyle is the style of the fitted curve.
+	LineStyle draw.LineStyle
+
+	// Samples is the number of points at which
+	// the curve is evaluated for drawing.
+	Samples int
+
+	// Confidence is the confidence level of the band
+	// drawn around the fitted curve, for example 0.95.
+	// No band is drawn if Confidence is zero. Bands are
+	// not drawn for a LoessFit.
+	Confidence float64
+
+	// BandColor is the color of the confidence band.
+	// If BandColor is nil, a translucent version of the
+	// line color is used.
+	BandColor color.Color
+
+	// EquationStyle is the style of the annotation giving
+	// the equation of the fit and its R². The annotation
+	// is drawn only if EquationStyle.Font.Size is not zero.
+	EquationStyle draw.TextStyle
+
+	// EquationFormat is the fmt package format string
+	// used to format coefficients in the annotation.
+	EquationFormat string
+
+	// EquationX and EquationY are the location of the top
+	// left corner of the annotation as fractions of the
+	// width and height of the plotting area.
+	EquationX, EquationY float64
+
+	// span is the fraction of points used in
+	// each local regression of a LoessFit.
+	span float64
+
+	// robustWeights are the robustness weights
+	// of the points of a LoessFit.
+	robustWeights []float64
+
+	// ls is the least-squares fit of a
+	// parametric model.
+	ls leastSquares
+
+	// shift and scale map X values to the variable
+	// of the basis of a polynomial or exponential
+	// fit, (x-shift)/scale, keeping the least-squares
+	// problem well conditioned for large X values.
+	shift, scale float64
+}
+
+// NewPolynomialFit returns a Fit of a polynomial of the
+// given degree to the points in xys.
+func NewPolynomialFit(xys XYer, degree int) (*Fit, error) {
+	if degree < 0 {
+		return nil, errors.New("Negative polynomial degree")
+	}
+	return newParametricFit(xys, PolynomialFit, degree+1)
+}
+
+// NewExponentialFit returns a Fit of y = c₀·exp(c₁x) to the
+// points in xys. The Y values must be positive.
+func NewExponentialFit(xys XYer) (*Fit, error) {
+	return newParametricFit(xys, ExponentialFit, 2)
+}
+
+// NewLogarithmicFit returns a Fit of y = c₀ + c₁·ln x to the
+// points in xys. The X values must be positive.
+func NewLogarithmicFit(xys XYer) (*Fit, error) {
+	return newParametricFit(xys, LogarithmicFit, 2)
+}
+
+// NewLoessFit returns a LOESS smoother of the points in xys.
+// Each point of the curve is found by a weighted linear
+// regression on the fraction span of the points nearest to
+// it, with tricube weights. If iterations is positive, that
+// number of robustness iterations are performed, reducing
+// the influence of outliers as in LOWESS.
+func NewLoessFit(xys XYer, span float64, iterations int) (*Fit, error) {
+	if span <= 0 || span > 1 {
+		return nil, errors.New("LOESS span not in (0, 1]")
+	}
+	f, err := newFit(xys, LoessFit)
+	if err != nil {
+		return nil, err
+	}
+	f.span = span
+	f.robustWeights = make([]float64, len(f.XYs))
+	for i := range f.robustWeights {
+		f.robustWeights[i] = 1
+	}
+	resid := make([]float64, len(f.XYs))
+	for it := 0; it < iterations; it++ {
+		for i, p := range f.XYs {
+			resid[i] = math.Abs(p.Y - f.Value(p.X))
+		}
+		sorted := append([]float64(nil), resid...)
+		sort.Float64s(sorted)
+		s := 6 * sorted[len(sorted)/2]
+		if s == 0 {
+			break
+		}
+		for i, r := range resid {
+			f.robustWeights[i] = 0
+			if r < s {
+				f.robustWeights[i] = sq(1 - sq(r/s))
+			}
+		}
+	}
+	f.RSquared = f.rSquared()
+	return f, nil
+}
+
+// newFit returns a Fit of the given model to xys with
+// default styles.
+func newFit(xys XYer, model FitModel) (*Fit, error) {
+	data, err := CopyXYs(xys)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrNoData
+	}
+	return &Fit{
+		XYs:       data,
+		Model:     model,
+		LineStyle: DefaultLineStyle,
+		Samples:   100,
+		EquationX: 0.02,
+		EquationY: 0.98,
+	}, nil
+}
+
+// newParametricFit returns a least-squares fit of the model
+// with p coefficients to xys.
+func newParametricFit(xys XYer, model FitModel, p int) (*Fit, error) {
+	f, err := newFit(xys, model)
+	if err != nil {
+		return nil, err
+	}
+	if len(f.XYs) < p {
+		return nil, errors.New("Too few points for fit")
+	}
+	f.shift, f.scale = 0, 1
+	if model == PolynomialFit || model == ExponentialFit {
+		xmin, xmax, _, _ := XYRange(f.XYs)
+		f.shift = (xmin + xmax) / 2
+		if xmax > xmin {
+			f.scale = (xmax - xmin) / 2
+		}
+	}
+	rows := make([][]float64, len(f.XYs))
+	ys := make([]float64, len(f.XYs))
+	for i, pt := range f.XYs {
+		switch model {
+		case ExponentialFit:
+			if pt.Y <= 0 {
+				return nil, errors.New("Non-positive Y value in exponential fit")
+			}
+		case LogarithmicFit:
+			if pt.X <= 0 {
+				return nil, errors.New("Non-positive X value in logarithmic fit")
+			}
+		}
+		rows[i] = f.basis(pt.X, p)
+		ys[i] = pt.Y
+		if model == ExponentialFit {
+			ys[i] = math.Log(pt.Y)
+		}
+	}
+	f.ls, err = fitLeastSquares(rows, ys)
+	if err != nil {
+		return nil, err
+	}
+	f.Coeffs = unscale(f.ls.beta, f.shift, f.scale)
+	if model == ExponentialFit {
+		f.Coeffs[0] = math.Exp(f.Coeffs[0])
+	}
+	f.RSquared = f.rSquared()
+	return f, nil
+}
+
+// unscale returns the coefficients of the polynomial in x
+// equal to the polynomial in (x-shift)/scale with the
+// coefficients beta.
+func unscale(beta []float64, shift, scale float64) []float64 {
+	c := make([]float64, len(beta))
+	// binom holds the binomial coefficients of
+	// the current power of (x-shift).
+	binom := []float64{1}
+	pow := 1.0
+	for j, b := range beta {
+		if j > 0 {
+			next := make([]float64, j+1)
+			for k := range next {
+				if k < j {
+					next[k] -= shift * binom[k]
+				}
+				if k > 0 {
+					next[k] += binom[k-1]
+				}
+			}
+			binom = next
+			pow *= scale
+		}
+		for k, v := range binom {
+			c[k] += b * v / pow
+		}
+	}
+	return c
+}
+
+// basis returns the p regressors of the linear
+// model of f at x.
+func (f *Fit) basis(x float64, p int) []float64 {
+	b := make([]float64, p)
+	switch f.Model {
+	case PolynomialFit:
+		v := 1.0
+		x = (x - f.shift) / f.scale
+		for k := range b {
+			b[k] = v
+			v *= x
+		}
+	case ExponentialFit:
+		b[0], b[1] = 1, (x-f.shift)/f.scale
+	case LogarithmicFit:
+		b[0], b[1] = 1, math.Log(x)
+	}
+	return b
+}
+
+// Value returns the value of the fitted curve at x.
+func (f *Fit) Value(x float64) float64 {
+	if f.Model == LoessFit {
+		return f.loess(x)
+	}
+	y, _ := f.ls.predict(f.basis(x, len(f.ls.beta)))
+	if f.Model == ExponentialFit {
+		y = math.Exp(y)
+	}
+	return y
+}
+
+// Band returns the lower and upper limits of the confidence
+// interval of the fitted curve at x at the given confidence
+// level. Band panics for a LoessFit.
+func (f *Fit) Band(x, confidence float64) (lo, hi float64) {
+	if f.Model == LoessFit {
+		panic("fit: no confidence band for LOESS fit")
+	}
+	y, se := f.ls.predict(f.basis(x, len(f.ls.beta)))
+	d := 0.0
+	if f.ls.dof > 0 {
+		d = studentTQuantile(1-(1-confidence)/2, float64(f.ls.dof)) * se
+	}
+	lo, hi = y-d, y+d
+	if f.Model == ExponentialFit {
+		lo, hi = math.Exp(lo), math.Exp(hi)
+	}
+	return lo, hi
+}
+
+// rSquared returns the coefficient of determination of f.
+func (f *Fit) rSquared() float64 {
+	var mean float64
+	for _, p := range f.XYs {
+		mean += p.Y
+	}
+	mean /= float64(len(f.XYs))
+	var res, tot float64
+	for _, p := range f.XYs {
+		res += sq(p.Y - f.Value(p.X))
+		tot += sq(p.Y - mean)
+	}
+	if tot == 0 {
+		return 1
+	}
+	return 1 - res/tot
+}
+
+// loess returns the value of the LOESS curve at x.
+func (f *Fit) loess(x float64) float64 {
+	n := len(f.XYs)
+	k := int(math.Ceil(f.span * float64(n)))
+	if k < 2 {
+		k = 2
+	}
+	if k > n {
+		k = n
+	}
+	dist := make([]float64, n)
+	for i, p := range f.XYs {
+		dist[i] = math.Abs(p.X - x)
+	}
+	sorted := append([]float64(nil), dist...)
+	sort.Float64s(sorted)
+	h := sorted[k-1]
+	if k == n {
+		// Widen the window when all points are used so
+		// that the farthest point has non-zero weight.
+		h *= 1 + 1e-6
+	}
+
+	var sw, sx, sy, sxx, sxy float64
+	for i, p := range f.XYs {
+		w := f.robustWeights[i]
+		if h > 0 {
+			if dist[i] >= h {
+				continue
+			}
+			w *= math.Pow(1-math.Pow(dist[i]/h, 3), 3)
+		} else if dist[i] != 0 {
+			continue
+		}
+		sw += w
+		sx += w * p.X
+		sy += w * p.Y
+		sxx += w * p.X * p.X
+		sxy += w * p.X * p.Y
+	}
+	if sw == 0 {
+		return math.NaN()
+	}
+	mx, my := sx/sw, sy/sw
+	varx := sxx/sw - mx*mx
+	if varx <= 1e-12*(1+mx*mx) {
+		return my
+	}
+	b := (sxy/sw - mx*my) / varx
+	return my + b*(x-mx)
+}
+
+// leastSquares is a linear least-squares fit.
+type leastSquares struct {
+	// beta holds the fitted coefficients.
+	beta []float64
+
+	// cov is the inverse of the normal matrix,
+	// which scaled by sigma2 is the covariance
+	// of beta.
+	cov [][]float64
+
+	// sigma2 is the estimated residual variance.
+	sigma2 float64
+
+	// dof is the residual degrees of freedom.
+	dof int
+}
+
+// fitLeastSquares returns the least-squares fit of the
+// linear model with the given rows of regressors to ys.
+func fitLeastSquares(rows [][]float64, ys []float64) (leastSquares, error) {
+	p := len(rows[0])
+	a := make([][]float64, p)
+	for j := range a {
+		a[j] = make([]float64, p)
+	}
+	rhs := make([]float64, p)
+	for i, r := range rows {
+		for j := range r {
+			for k := range r {
+				a[j][k] += r[j] * r[k]
+			}
+			rhs[j] += r[j] * ys[i]
+		}
+	}
+	inv, ok := invert(a)
+	if !ok {
+		return leastSquares{}, errors.New("Singular fit")
+	}
+	ls := leastSquares{
+		beta: make([]float64, p),
+		cov:  inv,
+		dof:  len(rows) - p,
+	}
+	for j := range ls.beta {
+		for k := range rhs {
+			ls.beta[j] += inv[j][k] * rhs[k]
+		}
+	}
+	if ls.dof > 0 {
+		var ss float64
+		for i, r := range rows {
+			y, _ := ls.predict(r)
+			ss += sq(ys[i] - y)
+		}
+		ls.sigma2 = ss / float64(ls.dof)
+	}
+	return ls, nil
+}
+
+// predict returns the fitted value for the regressors
+// r and its standard error.
+func (ls leastSquares) predict(r []float64) (y, se float64) {
+	var v float64
+	for j := range r {
+		y += ls.beta[j] * r[j]
+		for k := range r {
+			v += r[j] * ls.cov[j][k] * r[k]
+		}
+	}
+	return y, math.Sqrt(math.Max(0, ls.sigma2*v))
+}
+
+// invert returns the inverse of the square matrix a found
+// by Gauss-Jordan elimination with partial pivoting, and
+// whether a is non-singular. The contents of a are
+// destroyed.
+func invert(a [][]float64) ([][]float64, bool) {
+	n := len(a)
+	inv := make([][]float64, n)
+	var scale float64
+	for i := range inv {
+		inv[i] = make([]float64, n)
+		inv[i][i] = 1
+		for _, v := range a[i] {
+			scale = math.Max(scale, math.Abs(v))
+		}
+	}
+	for col := 0; col < n; col++ {
+		piv := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(a[r][col]) > math.Abs(a[piv][col]) {
+				piv = r
+			}
+		}
+		if math.Abs(a[piv][col]) <= 1e-14*scale {
+			return nil, false
+		}
+		a[col], a[piv] = a[piv], a[col]
+		inv[col], inv[piv] = inv[piv], inv[col]
+		d := a[col][col]
+		for k := 0; k < n; k++ {
+			a[col][k] /= d
+			inv[col][k] /= d
+		}
+		for r := 0; r < n; r++ {
+			if r == col || a[r][col] == 0 {
+				continue
+			}
+			m := a[r][col]
+			for k := 0; k < n; k++ {
+				a[r][k] -= m * a[col][k]
+				inv[r][k] -= m * inv[col][k]
+			}
+		}
+	}
+	return inv, true
+}
+
+// studentTQuantile returns the p quantile of Student's t
+// distribution with dof degrees of freedom. The two-sided
+// tail probability P(|T| > t) is I_x(dof/2, 1/2), the
+// regularized incomplete beta function at x = dof/(dof+t²),
+// which is inverted by bisection.
+func studentTQuantile(p, dof float64) float64 {
+	if p == 0.5 {
+		return 0
+	}
+	tail := 2 * math.Min(p, 1-p)
+	a, b := dof/2, 0.5
+	lo, hi := 0.0, 1.0
+	for i := 0; i < 100; i++ {
+		mid := (lo + hi) / 2
+		if betaInc(mid, a, b) < tail {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	x := (lo + hi) / 2
+	t := math.Sqrt(dof * (1 - x) / x)
+	if p < 0.5 {
+		return -t
+	}
+	return t
+}
+
+// betaInc returns the regularized incomplete beta function
+// I_x(a, b), evaluated by its continued fraction as in
+// Numerical Recipes §6.4.
+func betaInc(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log1p(-x))
+	// The continued fraction converges rapidly
+	// for x below the mean of the distribution.
+	if x < (a+1)/(a+b+2) {
+		return front * betaContFrac(x, a, b) / a
+	}
+	return 1 - front*betaContFrac(1-x, b, a)/b
+}
+
+// betaContFrac evaluates the continued fraction of the
+// incomplete beta function by the modified Lentz method.
+func betaContFrac(x, a, b float64) float64 {
+	const (
+		tiny = 1e-300
+		eps  = 1e-15
+	)
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1; m <= 300; m++ {
+		fm := float64(m)
+		for _, num := range []float64{
+			fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm)),
+			-(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1)),
+		} {
+			d = 1 + num*d
+			if math.Abs(d) < tiny {
+				d = tiny
+			}
+			c = 1 + num/c
+			if math.Abs(c) < tiny {
+				c = tiny
+			}
+			d = 1 / d
+			h *= d * c
+		}
+		if math.Abs(d*c-1) < eps {
+			break
+		}
+	}
+	return h
+}
+
+// EnableEquation sets the equation of the fit and its R² to
+// be drawn in the plotting area, with coefficients formatted
+// using the given fmt package format string, drawn with the
+// DefaultFont and DefaultFontSize. If format is empty, "%.3g"
+// is used.
+func (f *Fit) EnableEquation(format string) error {
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return err
+	}
+	if format == "" {
+		format = "%.3g"
+	}
+	f.EquationFormat = format
+	f.EquationStyle = draw.TextStyle{
+		Font:   fnt,
+		YAlign: draw.YTop,
+	}
+	return nil
+}
+
+// Equation returns the text of the fitted equation and
+// its R², with coefficients formatted using EquationFormat.
+func (f *Fit) Equation() string {
+	format := f.EquationFormat
+	if format == "" {
+		format = "%.3g"
+	}
+	num := func(v float64) string { return fmt.Sprintf(format, v) }
+	r2 := "R² = " + fmt.Sprintf("%.3f", f.RSquared)
+
+	var eq string
+	switch f.Model {
+	case PolynomialFit:
+		var terms []string
+		for k := len(f.Coeffs) - 1; k >= 0; k-- {
+			c := f.Coeffs[k]
+			if c == 0 && len(f.Coeffs) > 1 {
+				continue
+			}
+			sign := " + "
+			if c < 0 {
+				sign = " − "
+			}
+			if len(terms) == 0 {
+				sign = ""
+				if c < 0 {
+					sign = "−"
+				}
+			}
+			t := num(math.Abs(c))
+			switch k {
+			case 0:
+			case 1:
+				t += "x"
+			default:
+				t += "x" + superscript(k)
+			}
+			terms = append(terms, sign+t)
+		}
+		eq = "y = " + strings.Join(terms, "")
+	case ExponentialFit:
+		eq = "y = " + num(f.Coeffs[0]) + " exp(" + num(f.Coeffs[1]) + "x)"
+	case LogarithmicFit:
+		sign := " + "
+		if f.Coeffs[1] < 0 {
+			sign = " − "
+		}
+		eq = "y = " + num(f.Coeffs[0]) + sign + num(math.Abs(f.Coeffs[1])) + " ln x"
+	case LoessFit:
+		return r2
+	}
+	return eq + "\n" + r2
+}
+
+// superscript returns the decimal digits of
+// the non-negative integer n as superscripts.
+func superscript(n int) string {
+	const digits = "⁰¹²³⁴⁵⁶⁷⁸⁹"
+	sup := []rune(digits)
+	var s []rune
+	for _, d := range fmt.Sprint(n) {
+		s = append(s, sup[d-'0'])
+	}
+	return string(s)
+}
+
+// curve returns the fitted curve and, if a band is drawn,
+// the lower and upper limits of the band sampled at f.Samples
+// points across the X range of the data.
+func (f *Fit) curve() (xys, lo, hi XYs) {
+	xmin, xmax, _, _ := XYRange(f.XYs)
+	n := f.Samples
+	if n < 2 {
+		n = 2
+	}
+	band := f.Confidence > 0 && f.Model != LoessFit
+	for i := 0; i < n; i++ {
+		x := xmin + (xmax-xmin)*float64(i)/float64(n-1)
+		xys = append(xys, struct{ X, Y float64 }{x, f.Value(x)})
+		if band {
+			l, h := f.Band(x, f.Confidence)
+			lo = append(lo, struct{ X, Y float64 }{x, l})
+			hi = append(hi, struct{ X, Y float64 }{x, h})
+		}
+	}
+	return xys, lo, hi
+}
+
+// bandColor returns the color of the confidence band.
+func (f *Fit) bandColor() color.Color {
+	if f.BandColor != nil {
+		return f.BandColor
+	}
+	col := f.LineStyle.Color
+	if col == nil {
+		col = color.Black
+	}
+	r, g, b, _ := col.RGBA()
+	return color.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: 64}
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (f *Fit) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	xys, lo, hi := f.curve()
+
+	if lo != nil {
+		poly := make([]vg.Point, 0, 2*len(lo))
+		for _, p := range hi {
+			poly = append(poly, vg.Point{X: trX(p.X), Y: trY(p.Y)})
+		}
+		for i := len(lo) - 1; i >= 0; i-- {
+			poly = append(poly, vg.Point{X: trX(lo[i].X), Y: trY(lo[i].Y)})
+		}
+		c.FillPolygon(f.bandColor(), c.ClipPolygonXY(poly))
+	}
+
+	ps := make([]vg.Point, 0, len(xys))
+	for _, p := range xys {
+		if math.IsNaN(p.Y) {
+			continue
+		}
+		ps = append(ps, vg.Point{X: trX(p.X), Y: trY(p.Y)})
+	}
+	c.StrokeLines(f.LineStyle, c.ClipLinesXY(ps)...)
+
+	if f.EquationStyle.Font.Size != 0 {
+		pt := vg.Point{
+			X: c.Min.X + vg.Length(f.EquationX)*(c.Max.X-c.Min.X),
+			Y: c.Min.Y + vg.Length(f.EquationY)*(c.Max.Y-c.Min.Y),
+		}
+		sty := f.EquationStyle
+		if sty.Color == nil {
+			sty.Color = f.LineStyle.Color
+		}
+		c.FillText(sty, pt, f.Equation())
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (f *Fit) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax, ymin, ymax = XYRange(f.XYs)
+	xys, lo, hi := f.curve()
+	for _, set := range []XYs{xys, lo, hi} {
+		for _, p := range set {
+			if math.IsNaN(p.Y) || math.IsInf(p.Y, 0) {
+				continue
+			}
+			ymin = math.Min(ymin, p.Y)
+			ymax = math.Max(ymax, p.Y)
+		}
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (f *Fit) Thumbnail(c *draw.Canvas) {
+	if f.Confidence > 0 && f.Model != LoessFit {
+		fillThumbnail(c, f.bandColor())
+	}
+	y := c.Center().Y
+	c.StrokeLine2(f.LineStyle, c.Min.X, y, c.Max.X, y)
+}
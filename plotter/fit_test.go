@@ -0,0 +1,236 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// ExampleFit draws noisy points with a quadratic fit and
+// its 95% confidence band, and a LOESS smoother.
+func ExampleFit() {
+	rnd := rand.New(rand.NewSource(1))
+
+	pts := make(XYs, 50)
+	for i := range pts {
+		x := 10 * rnd.Float64()
+		pts[i].X = x
+		pts[i].Y = 0.3*x*x - 2*x + 4 + rnd.NormFloat64()
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Fits"
+
+	s, err := NewScatter(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	s.Radius = vg.Points(2)
+
+	poly, err := NewPolynomialFit(pts, 2)
+	if err != nil {
+		log.Panic(err)
+	}
+	poly.Confidence = 0.95
+	poly.LineStyle.Color = color.RGBA{B: 200, A: 255}
+	poly.LineStyle.Width = vg.Points(1)
+	err = poly.EnableEquation("%.2f")
+	if err != nil {
+		log.Panic(err)
+	}
+
+	loess, err := NewLoessFit(pts, 0.4, 2)
+	if err != nil {
+		log.Panic(err)
+	}
+	loess.LineStyle.Color = color.RGBA{R: 200, A: 255}
+	loess.LineStyle.Width = vg.Points(1)
+	loess.LineStyle.Dashes = []vg.Length{vg.Points(4), vg.Points(2)}
+
+	p.Add(s, poly, loess)
+	p.Legend.Add("quadratic", poly)
+	p.Legend.Add("LOESS", loess)
+
+	err = p.Save(250, 200, "testdata/fit.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestFit(t *testing.T) {
+	checkPlot(ExampleFit, t, "fit.png")
+}
+
+func TestParametricFits(t *testing.T) {
+	const tol = 1e-9
+	for _, test := range []struct {
+		name string
+		f    func(x float64) float64
+		fit  func(XYer) (*Fit, error)
+		want []float64
+	}{
+		{
+			name: "cubic",
+			f:    func(x float64) float64 { return 1 - 2*x + 0.5*x*x*x },
+			fit:  func(xys XYer) (*Fit, error) { return NewPolynomialFit(xys, 3) },
+			want: []float64{1, -2, 0, 0.5},
+		},
+		{
+			name: "exponential",
+			f:    func(x float64) float64 { return 3 * math.Exp(-0.5*x) },
+			fit:  NewExponentialFit,
+			want: []float64{3, -0.5},
+		},
+		{
+			name: "logarithmic",
+			f:    func(x float64) float64 { return 2 + 4*math.Log(x) },
+			fit:  NewLogarithmicFit,
+			want: []float64{2, 4},
+		},
+	} {
+		xys := make(XYs, 10)
+		for i := range xys {
+			xys[i].X = float64(i + 1)
+			xys[i].Y = test.f(xys[i].X)
+		}
+		f, err := test.fit(xys)
+		if err != nil {
+			t.Errorf("unexpected error for %s fit: %v", test.name, err)
+			continue
+		}
+		if !equalFloatsTol(f.Coeffs, test.want, tol) {
+			t.Errorf("unexpected coefficients for %s fit: got:%v want:%v", test.name, f.Coeffs, test.want)
+		}
+		if math.Abs(f.RSquared-1) > tol {
+			t.Errorf("unexpected R² for exact %s fit: got:%v want:1", test.name, f.RSquared)
+		}
+		if lo, hi := f.Band(2.5, 0.95); math.Abs(hi-lo) > 1e-6 {
+			t.Errorf("unexpected band width for exact %s fit: got:%v want:0", test.name, hi-lo)
+		}
+	}
+
+	if _, err := NewExponentialFit(XYs{{0, 1}, {1, -1}}); err == nil {
+		t.Error("expected error for non-positive exponential fit data")
+	}
+	if _, err := NewPolynomialFit(XYs{{1, 1}, {1, 2}, {1, 3}}, 1); err == nil {
+		t.Error("expected error for singular fit")
+	}
+}
+
+func TestPolynomialFitOffset(t *testing.T) {
+	for _, test := range []struct {
+		degree int
+		x0, dx float64
+		n      int
+	}{
+		{degree: 1, x0: 1.4e9, dx: 3600, n: 100},
+		{degree: 2, x0: 1e6, dx: 1, n: 50},
+		{degree: 3, x0: 0, dx: 3600, n: 51},
+	} {
+		// f is a polynomial of the given degree in the
+		// distance from the middle of the X values.
+		mid := test.x0 + test.dx*float64(test.n-1)/2
+		span := test.dx * float64(test.n-1) / 2
+		f := func(x float64) float64 {
+			u := (x - mid) / span
+			y := 1.0
+			for k := 1; k <= test.degree; k++ {
+				y += float64(k) * math.Pow(u, float64(k))
+			}
+			return y
+		}
+		xys := make(XYs, test.n)
+		for i := range xys {
+			xys[i].X = test.x0 + test.dx*float64(i)
+			xys[i].Y = f(xys[i].X)
+		}
+		fit, err := NewPolynomialFit(xys, test.degree)
+		if err != nil {
+			t.Errorf("unexpected error for degree %d fit at offset %v: %v", test.degree, test.x0, err)
+			continue
+		}
+		for _, p := range xys {
+			if got := fit.Value(p.X); math.Abs(got-p.Y) > 1e-6 {
+				t.Errorf("unexpected value for degree %d fit at %v: got:%v want:%v", test.degree, p.X, got, p.Y)
+				break
+			}
+		}
+		if math.Abs(fit.RSquared-1) > 1e-9 {
+			t.Errorf("unexpected R² for degree %d fit: got:%v want:1", test.degree, fit.RSquared)
+		}
+	}
+}
+
+func TestLoessFit(t *testing.T) {
+	xys := make(XYs, 20)
+	for i := range xys {
+		xys[i].X = float64(i)
+		xys[i].Y = 2*float64(i) - 3
+	}
+	// An outlier is ignored after robustness iterations.
+	xys[10].Y += 100
+
+	f, err := NewLoessFit(xys, 0.5, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, x := range []float64{0, 4.5, 10, 19} {
+		if got, want := f.Value(x), 2*x-3; math.Abs(got-want) > 1e-6 {
+			t.Errorf("unexpected LOESS value at %v: got:%v want:%v", x, got, want)
+		}
+	}
+}
+
+func TestStudentTQuantile(t *testing.T) {
+	for _, test := range []struct {
+		p, dof, want float64
+	}{
+		{0.975, 1, 12.706},
+		{0.975, 2, 4.303},
+		{0.975, 3, 3.182},
+		{0.975, 4, 2.776},
+		{0.975, 5, 2.571},
+		{0.995, 1, 63.657},
+		{0.995, 3, 5.841},
+		{0.025, 2, -4.303},
+		{0.975, 10, 2.228},
+		{0.975, 30, 2.042},
+		{0.95, 5, 2.015},
+		{0.5, 4, 0},
+	} {
+		if got := studentTQuantile(test.p, test.dof); math.Abs(got-test.want) > 1e-3 {
+			t.Errorf("unexpected t quantile for p=%v dof=%v: got:%v want:%v", test.p, test.dof, got, test.want)
+		}
+	}
+}
+
+func TestFitEquation(t *testing.T) {
+	f := &Fit{Model: PolynomialFit, Coeffs: []float64{-4, 0, 2.5}, RSquared: 0.9876}
+	if got, want := f.Equation(), "y = 2.5x² − 4\nR² = 0.988"; got != want {
+		t.Errorf("unexpected equation: got:%q want:%q", got, want)
+	}
+}
+
+func equalFloatsTol(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,337 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// GraphEdge is an edge of a Graph.
+type GraphEdge struct {
+	// From and To are the indices of
+	// the nodes joined by the edge.
+	From, To int
+
+	// Width is the width of the edge. If it is
+	// zero, the width of the Graph's EdgeStyle
+	// is used.
+	Width vg.Length
+}
+
+// Graph implements the Plotter interface, drawing a graph of
+// nodes joined by edges. The nodes are drawn as glyphs at the
+// points in XYs, which may be set directly or by one of the
+// layout methods, with their labels to the right.
+type Graph struct {
+	// XYs holds the position of each node.
+	XYs
+
+	// Labels holds the label of each node.
+	Labels []string
+
+	// Edges holds the edges of the graph.
+	Edges []GraphEdge
+
+	// GlyphStyle is the style of the nodes.
+	draw.GlyphStyle
+
+	// EdgeStyle is the style of the edges.
+	EdgeStyle draw.LineStyle
+
+	// ArrowSize is the length of the arrow heads
+	// drawn at the To end of each edge. If it is
+	// zero, the graph is drawn undirected.
+	ArrowSize vg.Length
+
+	// TextStyle is the style of the labels.
+	TextStyle draw.TextStyle
+}
+
+// NewGraph returns a Graph with a node for each of the labels,
+// joined by the edges, laid out with LayoutCircular.
+func NewGraph(labels []string, edges []GraphEdge) (*Graph, error) {
+	if len(labels) == 0 {
+		return nil, ErrNoData
+	}
+	for _, e := range edges {
+		if e.From < 0 || e.From >= len(labels) || e.To < 0 || e.To >= len(labels) {
+			return nil, errors.New("Graph edge node out of range")
+		}
+	}
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	g := &Graph{
+		XYs:        make(XYs, len(labels)),
+		Labels:     labels,
+		Edges:      edges,
+		GlyphStyle: DefaultGlyphStyle,
+		EdgeStyle:  DefaultLineStyle,
+		TextStyle:  draw.TextStyle{Font: fnt},
+	}
+	g.GlyphStyle.Shape = draw.CircleGlyph{}
+	g.GlyphStyle.Radius = vg.Points(4)
+	g.LayoutCircular()
+	return g, nil
+}
+
+// LayoutCircular places the nodes in order clockwise
+// around the unit circle, starting at the top.
+func (g *Graph) LayoutCircular() {
+	n := len(g.XYs)
+	for i := range g.XYs {
+		a := math.Pi/2 - 2*math.Pi*float64(i)/float64(n)
+		g.XYs[i].X, g.XYs[i].Y = math.Cos(a), math.Sin(a)
+	}
+}
+
+// LayoutForce places the nodes with the force-directed algorithm
+// of Fruchterman and Reingold within the unit square, starting from
+// random positions drawn from a source with the given seed, so that
+// the layout is reproducible. Linked nodes attract and all nodes
+// repel, and the movement of the nodes is limited by a temperature
+// that cools linearly over the iterations.
+func (g *Graph) LayoutForce(iterations int, seed int64) {
+	n := len(g.XYs)
+	rnd := rand.New(rand.NewSource(seed))
+	for i := range g.XYs {
+		g.XYs[i].X, g.XYs[i].Y = rnd.Float64(), rnd.Float64()
+	}
+	k := 0.75 * math.Sqrt(1/float64(n))
+	dx := make([]float64, n)
+	dy := make([]float64, n)
+	for it := 0; it < iterations; it++ {
+		for i := range dx {
+			dx[i], dy[i] = 0, 0
+		}
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				x, y, d := g.separation(i, j)
+				f := k * k / d
+				dx[i] += x / d * f
+				dy[i] += y / d * f
+				dx[j] -= x / d * f
+				dy[j] -= y / d * f
+			}
+		}
+		for _, e := range g.Edges {
+			if e.From == e.To {
+				continue
+			}
+			x, y, d := g.separation(e.From, e.To)
+			f := d * d / k
+			dx[e.From] -= x / d * f
+			dy[e.From] -= y / d * f
+			dx[e.To] += x / d * f
+			dy[e.To] += y / d * f
+		}
+
+		t := 0.1 * (1 - float64(it)/float64(iterations))
+		for i := range g.XYs {
+			d := math.Hypot(dx[i], dy[i])
+			if d == 0 {
+				continue
+			}
+			m := math.Min(d, t)
+			g.XYs[i].X = math.Min(1, math.Max(0, g.XYs[i].X+dx[i]/d*m))
+			g.XYs[i].Y = math.Min(1, math.Max(0, g.XYs[i].Y+dy[i]/d*m))
+		}
+	}
+}
+
+// separation returns the vector from node j to node i
+// and its length, which is never less than a small
+// positive distance.
+func (g *Graph) separation(i, j int) (x, y, d float64) {
+	const minDist = 1e-6
+	x, y = g.XYs[i].X-g.XYs[j].X, g.XYs[i].Y-g.XYs[j].Y
+	d = math.Hypot(x, y)
+	if d < minDist {
+		// Separate coincident nodes in
+		// a fixed direction.
+		x, y, d = minDist*float64(i-j), 0, minDist*math.Abs(float64(i-j))
+	}
+	return x, y, d
+}
+
+// LayoutLayered places the nodes in horizontal layers, with the
+// edges pointing down. Each node is placed in the layer below the
+// lowest of its predecessors; the nodes of each cycle of the graph,
+// found as strongly connected components, share a layer. The nodes
+// of each layer are ordered by the barycenters of their neighbours
+// in the adjacent layers to reduce the crossings of the edges.
+// Layers are one unit apart, as are the nodes within a layer.
+func (g *Graph) LayoutLayered() {
+	n := len(g.XYs)
+	adj := make(graph, n)
+	for _, e := range g.Edges {
+		if adj[e.From] == nil {
+			adj[e.From] = make(set)
+		}
+		adj[e.From][e.To] = struct{}{}
+	}
+
+	// Tarjan's algorithm finds the strongly connected
+	// components in reverse topological order.
+	sccs := newTarjan(adj).sccs
+	comp := make([]int, n)
+	for c, scc := range sccs {
+		for _, u := range scc {
+			comp[u] = c
+		}
+	}
+	compLayer := make([]int, len(sccs))
+	var depth int
+	for c := len(sccs) - 1; c >= 0; c-- {
+		for _, u := range sccs[c] {
+			for v := range adj[u] {
+				if comp[v] != c && compLayer[comp[v]] < compLayer[c]+1 {
+					compLayer[comp[v]] = compLayer[c] + 1
+				}
+			}
+		}
+		if compLayer[c] > depth {
+			depth = compLayer[c]
+		}
+	}
+
+	layers := make([][]int, depth+1)
+	layer := make([]int, n)
+	for u := range layer {
+		layer[u] = compLayer[comp[u]]
+		layers[layer[u]] = append(layers[layer[u]], u)
+	}
+	place := func(l []int) {
+		for i, u := range l {
+			g.XYs[u].X = float64(i) - float64(len(l)-1)/2
+			g.XYs[u].Y = float64(depth - layer[u])
+		}
+	}
+	for _, l := range layers {
+		place(l)
+	}
+
+	// Order the layers by barycenters, sweeping down
+	// using the neighbours in the layers above, then
+	// up using those below.
+	const sweeps = 4
+	barycenter := func(u int, above bool) float64 {
+		var sum float64
+		var cnt int
+		for _, e := range g.Edges {
+			v := e.To
+			if v == u {
+				v = e.From
+			} else if e.From != u {
+				continue
+			}
+			if (above && layer[v] < layer[u]) || (!above && layer[v] > layer[u]) {
+				sum += g.XYs[v].X
+				cnt++
+			}
+		}
+		if cnt == 0 {
+			return g.XYs[u].X
+		}
+		return sum / float64(cnt)
+	}
+	for s := 0; s < sweeps; s++ {
+		for i := 1; i < len(layers); i++ {
+			l := layers[i]
+			sort.Stable(byKey{l, func(u int) float64 { return barycenter(u, true) }})
+			place(l)
+		}
+		for i := len(layers) - 2; i >= 0; i-- {
+			l := layers[i]
+			sort.Stable(byKey{l, func(u int) float64 { return barycenter(u, false) }})
+			place(l)
+		}
+	}
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (g *Graph) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	pt := func(i int) vg.Point {
+		return vg.Point{X: trX(g.XYs[i].X), Y: trY(g.XYs[i].Y)}
+	}
+
+	for _, e := range g.Edges {
+		if e.From == e.To {
+			continue
+		}
+		from, to := pt(e.From), pt(e.To)
+		sty := g.EdgeStyle
+		if e.Width != 0 {
+			sty.Width = e.Width
+		}
+		if g.ArrowSize > 0 {
+			// End the edge at the edge of
+			// the glyph of the target.
+			d := to.Sub(from)
+			l := vg.Length(math.Hypot(float64(d.X), float64(d.Y)))
+			if l <= g.Radius {
+				continue
+			}
+			to = to.Sub(d.Scale(g.Radius / l))
+			shaft := to.Sub(d.Scale(g.ArrowSize / l / 2))
+			c.StrokeLines(sty, c.ClipLinesXY([]vg.Point{from, shaft})...)
+			fillArrowHead(&c, sty.Color, from, to, g.ArrowSize)
+			continue
+		}
+		c.StrokeLines(sty, c.ClipLinesXY([]vg.Point{from, to})...)
+	}
+
+	sty := g.TextStyle
+	sty.YAlign = draw.YCenter
+	gap := g.Radius + g.TextStyle.Width(" ")
+	for i := range g.XYs {
+		p := pt(i)
+		c.DrawGlyph(g.GlyphStyle, p)
+		if i < len(g.Labels) && g.Labels[i] != "" && c.Contains(p) {
+			c.FillText(sty, vg.Point{X: p.X + gap, Y: p.Y}, g.Labels[i])
+		}
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (g *Graph) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return XYRange(g.XYs)
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface, including
+// the labels of the nodes.
+func (g *Graph) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, len(g.XYs))
+	gap := g.Radius + g.TextStyle.Width(" ")
+	for i, p := range g.XYs {
+		bs[i].X = plt.X.Norm(p.X)
+		bs[i].Y = plt.Y.Norm(p.Y)
+		bs[i].Rectangle = g.GlyphStyle.Rectangle()
+		if i < len(g.Labels) && g.Labels[i] != "" {
+			h := g.TextStyle.Height(g.Labels[i]) / 2
+			bs[i].Rectangle.Max.X = gap + g.TextStyle.Width(g.Labels[i])
+			if h > bs[i].Rectangle.Max.Y {
+				bs[i].Rectangle.Min.Y, bs[i].Rectangle.Max.Y = -h, h
+			}
+		}
+	}
+	return bs
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (g *Graph) Thumbnail(c *draw.Canvas) {
+	c.DrawGlyph(g.GlyphStyle, c.Center())
+}
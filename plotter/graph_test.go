@@ -0,0 +1,126 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// ExampleGraph draws a network of services
+// with a seeded force-directed layout.
+func ExampleGraph() {
+	labels := []string{"gateway", "auth", "users", "orders", "search", "db", "cache", "queue", "mailer"}
+	edges := []GraphEdge{
+		{From: 0, To: 1}, {From: 0, To: 2}, {From: 0, To: 3}, {From: 0, To: 4},
+		{From: 1, To: 2}, {From: 2, To: 5, Width: vg.Points(2)},
+		{From: 3, To: 5, Width: vg.Points(2)}, {From: 4, To: 6},
+		{From: 2, To: 6}, {From: 3, To: 7}, {From: 7, To: 8},
+	}
+	g, err := NewGraph(labels, edges)
+	if err != nil {
+		log.Panic(err)
+	}
+	g.LayoutForce(200, 1)
+	g.ArrowSize = vg.Points(6)
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Service graph"
+	p.HideAxes()
+	p.Add(g)
+
+	err = p.Save(300, 250, "testdata/graph.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestGraph(t *testing.T) {
+	checkPlot(ExampleGraph, t, "graph.png")
+}
+
+// ExampleGraph_layered draws a directed graph
+// with a cycle in layers.
+func ExampleGraph_layered() {
+	labels := []string{"a", "b", "c", "d", "e", "f", "g"}
+	edges := []GraphEdge{
+		{From: 0, To: 2}, {From: 1, To: 3}, {From: 0, To: 3},
+		{From: 2, To: 4}, {From: 3, To: 5}, {From: 5, To: 3},
+		{From: 4, To: 6}, {From: 5, To: 6},
+	}
+	g, err := NewGraph(labels, edges)
+	if err != nil {
+		log.Panic(err)
+	}
+	g.LayoutLayered()
+	g.ArrowSize = vg.Points(6)
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Layered graph"
+	p.HideAxes()
+	p.Add(g)
+
+	err = p.Save(200, 200, "testdata/graphLayered.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestGraphLayered(t *testing.T) {
+	checkPlot(ExampleGraph_layered, t, "graphLayered.png")
+}
+
+func TestGraphLayouts(t *testing.T) {
+	labels := make([]string, 4)
+	edges := []GraphEdge{{From: 0, To: 1}, {From: 1, To: 2}, {From: 2, To: 1}, {From: 0, To: 3}}
+	g, err := NewGraph(labels, edges)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// NewGraph lays the nodes out in a circle.
+	want := XYs{{0, 1}, {1, 0}, {0, -1}, {-1, 0}}
+	for i, p := range g.XYs {
+		if math.Abs(p.X-want[i].X) > 1e-12 || math.Abs(p.Y-want[i].Y) > 1e-12 {
+			t.Errorf("unexpected circular position of node %d: got:%v want:%v", i, p, want[i])
+		}
+	}
+
+	// Nodes 1 and 2 form a cycle and so share a layer.
+	g.LayoutLayered()
+	wantY := []float64{1, 0, 0, 0}
+	for i, p := range g.XYs {
+		if p.Y != wantY[i] {
+			t.Errorf("unexpected layer of node %d: got:%v want:%v", i, p.Y, wantY[i])
+		}
+	}
+
+	g.LayoutForce(100, 1)
+	first := append(XYs(nil), g.XYs...)
+	g.LayoutForce(100, 1)
+	for i := range first {
+		if g.XYs[i] != first[i] {
+			t.Errorf("force layout of node %d not reproducible: got:%v want:%v", i, g.XYs[i], first[i])
+		}
+		if p := g.XYs[i]; p.X < 0 || p.X > 1 || p.Y < 0 || p.Y > 1 {
+			t.Errorf("node %d outside unit square: %v", i, p)
+		}
+	}
+
+	_, err = NewGraph(labels, []GraphEdge{{From: 0, To: 4}})
+	if err == nil {
+		t.Error("expected error for edge out of range")
+	}
+}
@@ -5,8 +5,11 @@
 package plotter
 
 import (
+	"fmt"
+	"image"
 	"image/color"
 	"math"
+	"sort"
 
 	"github.com/gonum/plot"
 	"github.com/gonum/plot/palette"
@@ -52,8 +55,58 @@ type HeatMap struct {
 	// Min and Max define the dynamic range of the
 	// heat map.
 	Min, Max float64
+
+	// NaN is the color used to fill heat map elements
+	// with NaN values. If NaN is nil, these elements
+	// are not drawn.
+	NaN color.Color
+
+	// Rasterize specifies that the heat map is drawn
+	// as a single image rather than a filled rectangle
+	// for each element. Rasterizing is faster for
+	// large grids and avoids seams between elements
+	// in vector formats.
+	Rasterize bool
+
+	// Interpolation is the method used to interpolate
+	// values between the centers of heat map elements
+	// when the heat map is rasterized.
+	Interpolation Interpolation
+
+	// AnnotationStyle is the style of the text giving
+	// the value of each heat map element. Elements are
+	// only annotated if the font size of AnnotationStyle
+	// is not zero. If the color of AnnotationStyle is nil,
+	// black or white is chosen to contrast with the color
+	// of each element.
+	AnnotationStyle draw.TextStyle
+
+	// AnnotationFormat is the fmt package format string
+	// used to format the value of each element. If it is
+	// empty, "%g" is used.
+	AnnotationFormat string
 }
 
+// Interpolation specifies how a rasterized heat map is
+// interpolated between the centers of its elements.
+type Interpolation int
+
+const (
+	// InterpolateNearest fills each element
+	// with a single color.
+	InterpolateNearest Interpolation = iota
+
+	// InterpolateBilinear interpolates values linearly
+	// between the centers of neighboring elements.
+	InterpolateBilinear
+
+	// InterpolateBicubic interpolates values using
+	// Catmull-Rom splines through the centers of
+	// neighboring elements. Interpolated values do
+	// not overshoot their neighboring values.
+	InterpolateBicubic
+)
+
 // NewHeatMap creates as new heat map plotter for the given data,
 // using the provided palette. If g has Min and Max methods that return
 // a float, those returned values are used to set the respective HeatMap
@@ -90,15 +143,54 @@ func NewHeatMap(g GridXYZ, p palette.Palette) *HeatMap {
 	}
 }
 
+// EnableAnnotations sets the elements of the heat map to be
+// annotated with their values formatted using the given fmt
+// package format string, drawn with the DefaultFont and
+// DefaultFontSize. If format is empty, "%g" is used.
+func (h *HeatMap) EnableAnnotations(format string) error {
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return err
+	}
+	if format == "" {
+		format = "%g"
+	}
+	h.AnnotationFormat = format
+	h.AnnotationStyle = draw.TextStyle{
+		Font:   fnt,
+		XAlign: draw.XCenter,
+		YAlign: draw.YCenter,
+	}
+	return nil
+}
+
 // Plot implements the Plot method of the plot.Plotter interface.
 func (h *HeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
 	pal := h.Palette.Colors()
 	if len(pal) == 0 {
 		panic("heatmap: empty palette")
 	}
-	// ps scales the palette uniformly across the data range.
-	ps := float64(len(pal)-1) / (h.Max - h.Min)
 
+	if h.Rasterize {
+		h.plotRaster(c, plt, pal)
+	} else {
+		h.plotCells(c, plt, pal)
+	}
+	if h.AnnotationStyle.Font.Size != 0 {
+		h.annotate(c, plt, pal)
+	}
+}
+
+// colorOf returns the color of an element with the value v.
+func (h *HeatMap) colorOf(pal []color.Color, v float64) color.Color {
+	if math.IsNaN(v) {
+		return h.NaN
+	}
+	return paletteColor(pal, v, h.Min, h.Max, h.Underflow, h.Overflow)
+}
+
+// plotCells draws each element of the heat map as a rectangle.
+func (h *HeatMap) plotCells(c draw.Canvas, plt *plot.Plot, pal []color.Color) {
 	trX, trY := plt.Transforms(&c)
 
 	var pa vg.Path
@@ -120,7 +212,7 @@ func (h *HeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
 
 		for j := 0; j < rows; j++ {
 			v := h.GridXYZ.Z(i, j)
-			if math.IsNaN(v) || math.IsInf(v, 0) {
+			if math.IsInf(v, 0) {
 				continue
 			}
 
@@ -152,16 +244,7 @@ func (h *HeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
 			pa.Line(vg.Point{X: x, Y: dy})
 			pa.Close()
 
-			var col color.Color
-			switch {
-			case v < h.Min:
-				col = h.Underflow
-			case v > h.Max:
-				col = h.Overflow
-			default:
-				col = pal[int((v-h.Min)*ps+0.5)] // Apply palette scaling.
-			}
-			if col != nil {
+			if col := h.colorOf(pal, v); col != nil {
 				c.SetColor(col)
 				c.Fill(pa)
 			}
@@ -169,6 +252,260 @@ func (h *HeatMap) Plot(c draw.Canvas, plt *plot.Plot) {
 	}
 }
 
+// plotRaster draws the heat map as a single image.
+func (h *HeatMap) plotRaster(c draw.Canvas, plt *plot.Plot, pal []color.Color) {
+	trX, trY := plt.Transforms(&c)
+
+	cols, rows := h.GridXYZ.Dims()
+	xs := make([]float64, cols)
+	for i := range xs {
+		xs[i] = h.GridXYZ.X(i)
+	}
+	ys := make([]float64, rows)
+	for j := range ys {
+		ys[j] = h.GridXYZ.Y(j)
+	}
+
+	// Work in canvas coordinates so that non-linear
+	// axis scales are handled without inversion.
+	xEdges, xCenters := canvasCells(xs, trX)
+	yEdges, yCenters := canvasCells(ys, trY)
+
+	// The cells are ordered so that their canvas coordinates
+	// ascend, for descending grid coordinates or inverted axes.
+	flipX := ascend(xEdges, xCenters)
+	flipY := ascend(yEdges, yCenters)
+	zs := make([]float64, cols*rows)
+	for j := 0; j < rows; j++ {
+		for i := 0; i < cols; i++ {
+			ci, cj := i, j
+			if flipX {
+				ci = cols - 1 - i
+			}
+			if flipY {
+				cj = rows - 1 - j
+			}
+			zs[cj*cols+ci] = h.GridXYZ.Z(i, j)
+		}
+	}
+
+	// Clip the image to the canvas.
+	r := vg.Rectangle{
+		Min: vg.Point{X: xEdges[0], Y: yEdges[0]},
+		Max: vg.Point{X: xEdges[cols], Y: yEdges[rows]},
+	}
+	if r.Min.X < c.Min.X {
+		r.Min.X = c.Min.X
+	}
+	if r.Min.Y < c.Min.Y {
+		r.Min.Y = c.Min.Y
+	}
+	if r.Max.X > c.Max.X {
+		r.Max.X = c.Max.X
+	}
+	if r.Max.Y > c.Max.Y {
+		r.Max.Y = c.Max.Y
+	}
+	size := r.Size()
+	if size.X <= 0 || size.Y <= 0 {
+		return
+	}
+	dpi := canvasDPI(c)
+	w := int(math.Ceil(size.X.Dots(dpi)))
+	ht := int(math.Ceil(size.Y.Dots(dpi)))
+
+	img := image.NewRGBA(image.Rect(0, 0, w, ht))
+	for py := 0; py < ht; py++ {
+		y := r.Max.Y - (vg.Length(py)+0.5)*size.Y/vg.Length(ht)
+		for px := 0; px < w; px++ {
+			x := r.Min.X + (vg.Length(px)+0.5)*size.X/vg.Length(w)
+
+			i := cellIndex(xEdges, x)
+			j := cellIndex(yEdges, y)
+			if i < 0 || i >= cols || j < 0 || j >= rows {
+				continue
+			}
+			v := zs[j*cols+i]
+			switch h.Interpolation {
+			case InterpolateNearest:
+			case InterpolateBilinear, InterpolateBicubic:
+				// NaN elements are only drawn within their own
+				// bounds, so they do not spread into neighbors.
+				if math.IsNaN(v) {
+					break
+				}
+				i, tx := centerIndex(xCenters, x)
+				j, ty := centerIndex(yCenters, y)
+				v = interpolate(zs, cols, rows, i, j, tx, ty, v, h.Interpolation == InterpolateBicubic)
+			default:
+				panic("heatmap: unknown interpolation")
+			}
+			if math.IsInf(v, 0) {
+				continue
+			}
+			if col := h.colorOf(pal, v); col != nil {
+				img.Set(px, py, col)
+			}
+		}
+	}
+	c.DrawImage(r, img)
+}
+
+// canvasCells returns the canvas coordinates of the edges and
+// centers of the elements located at the data coordinates vs.
+// The edges are placed midway between neighboring elements.
+func canvasCells(vs []float64, tr func(float64) vg.Length) (edges, centers []vg.Length) {
+	n := len(vs)
+	edges = make([]vg.Length, n+1)
+	centers = make([]vg.Length, n)
+	if n == 1 {
+		edges[0], edges[1] = tr(vs[0]-0.5), tr(vs[0]+0.5)
+		centers[0] = tr(vs[0])
+		return edges, centers
+	}
+	edges[0] = tr(vs[0] - (vs[1]-vs[0])/2)
+	for i := 1; i < n; i++ {
+		edges[i] = tr((vs[i-1] + vs[i]) / 2)
+	}
+	edges[n] = tr(vs[n-1] + (vs[n-1]-vs[n-2])/2)
+	for i, v := range vs {
+		centers[i] = tr(v)
+	}
+	return edges, centers
+}
+
+// ascend reverses the edges and centers of cells if they
+// descend, returning whether they were reversed.
+func ascend(edges, centers []vg.Length) bool {
+	if edges[0] <= edges[len(edges)-1] {
+		return false
+	}
+	for i, j := 0, len(edges)-1; i < j; i, j = i+1, j-1 {
+		edges[i], edges[j] = edges[j], edges[i]
+	}
+	for i, j := 0, len(centers)-1; i < j; i, j = i+1, j-1 {
+		centers[i], centers[j] = centers[j], centers[i]
+	}
+	return true
+}
+
+// cellIndex returns the index of the element between the
+// ascending edges holding x.
+func cellIndex(edges []vg.Length, x vg.Length) int {
+	return sort.Search(len(edges), func(i int) bool { return edges[i] > x }) - 1
+}
+
+// centerIndex returns the index i of the last of the ascending
+// centers not greater than x and the fractional position of x
+// between centers i and i+1. Positions outside the centers are
+// clamped to the first or last center.
+func centerIndex(centers []vg.Length, x vg.Length) (i int, t float64) {
+	i = sort.Search(len(centers), func(i int) bool { return centers[i] > x }) - 1
+	switch {
+	case i < 0:
+		return 0, 0
+	case i >= len(centers)-1:
+		return len(centers) - 1, 0
+	}
+	return i, float64((x - centers[i]) / (centers[i+1] - centers[i]))
+}
+
+// interpolate returns the value interpolated at the fractional
+// position tx, ty from element (i, j) of the cols×rows row-major
+// grid zs. NaN values in zs are replaced by nan. Bicubic
+// interpolation uses Catmull-Rom splines with the result clamped
+// to the range of the contributing values.
+func interpolate(zs []float64, cols, rows, i, j int, tx, ty, nan float64, bicubic bool) float64 {
+	at := func(i, j int) float64 {
+		i = clampIndex(i, cols)
+		j = clampIndex(j, rows)
+		if z := zs[j*cols+i]; !math.IsNaN(z) {
+			return z
+		}
+		return nan
+	}
+	if !bicubic {
+		bottom := at(i, j)*(1-tx) + at(i+1, j)*tx
+		top := at(i, j+1)*(1-tx) + at(i+1, j+1)*tx
+		return bottom*(1-ty) + top*ty
+	}
+
+	wx, wy := catmullRom(tx), catmullRom(ty)
+	var v float64
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for n := 0; n < 4; n++ {
+		for m := 0; m < 4; m++ {
+			z := at(i+m-1, j+n-1)
+			v += wx[m] * wy[n] * z
+			lo = math.Min(lo, z)
+			hi = math.Max(hi, z)
+		}
+	}
+	return math.Max(lo, math.Min(hi, v))
+}
+
+// catmullRom returns the Catmull-Rom spline weights of the four
+// points surrounding the fractional position t.
+func catmullRom(t float64) [4]float64 {
+	t2, t3 := t*t, t*t*t
+	return [4]float64{
+		(-t3 + 2*t2 - t) / 2,
+		(3*t3 - 5*t2 + 2) / 2,
+		(-3*t3 + 4*t2 + t) / 2,
+		(t3 - t2) / 2,
+	}
+}
+
+// clampIndex returns i clamped to the range [0, n).
+func clampIndex(i, n int) int {
+	if i < 0 {
+		return 0
+	}
+	if i >= n {
+		return n - 1
+	}
+	return i
+}
+
+// annotate draws the value of each element at its center.
+func (h *HeatMap) annotate(c draw.Canvas, plt *plot.Plot, pal []color.Color) {
+	trX, trY := plt.Transforms(&c)
+	format := h.AnnotationFormat
+	if format == "" {
+		format = "%g"
+	}
+	cols, rows := h.GridXYZ.Dims()
+	for i := 0; i < cols; i++ {
+		for j := 0; j < rows; j++ {
+			v := h.GridXYZ.Z(i, j)
+			col := h.colorOf(pal, v)
+			if col == nil || math.IsInf(v, 0) {
+				continue
+			}
+			pt := vg.Point{X: trX(h.GridXYZ.X(i)), Y: trY(h.GridXYZ.Y(j))}
+			if !c.Contains(pt) {
+				continue
+			}
+			sty := h.AnnotationStyle
+			if sty.Color == nil {
+				sty.Color = contrasting(col)
+			}
+			c.FillText(sty, pt, fmt.Sprintf(format, v))
+		}
+	}
+}
+
+// contrasting returns black or white, whichever contrasts
+// best with col.
+func contrasting(col color.Color) color.Color {
+	r, g, b, _ := col.RGBA()
+	// Relative luminance of the color using the ITU-R BT.601 weights.
+	if 0.299*float64(r)+0.587*float64(g)+0.114*float64(b) > 0.5*0xffff {
+		return color.Black
+	}
+	return color.White
+}
+
 // DataRange implements the DataRange method
 // of the plot.DataRanger interface.
 func (h *HeatMap) DataRange() (xmin, xmax, ymin, ymax float64) {
@@ -189,6 +526,13 @@ func (h *HeatMap) DataRange() (xmin, xmax, ymin, ymax float64) {
 		ymax = h.GridXYZ.Y(r-1) + (h.GridXYZ.Y(r-1)-h.GridXYZ.Y(r-2))/2
 		ymin = h.GridXYZ.Y(0) - (h.GridXYZ.Y(1)-h.GridXYZ.Y(0))/2
 	}
+	// The ends are swapped for descending coordinates.
+	if xmin > xmax {
+		xmin, xmax = xmax, xmin
+	}
+	if ymin > ymax {
+		ymin, ymax = ymax, ymin
+	}
 	return xmin, xmax, ymin, ymax
 }
 
@@ -5,12 +5,19 @@
 package plotter
 
 import (
+	"image"
+	"image/color"
 	"log"
+	"math"
+	"reflect"
 	"testing"
 
 	"github.com/gonum/matrix/mat64"
 	"github.com/gonum/plot"
 	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
 )
 
 type offsetUnitGrid struct {
@@ -69,3 +76,215 @@ func ExampleHeatMap() {
 func TestHeatMap(t *testing.T) {
 	checkPlot(ExampleHeatMap, t, "heatMap.png")
 }
+
+// nonUniformGrid is a GridXYZ with arbitrary
+// column and row coordinates.
+type nonUniformGrid struct {
+	xs, ys []float64
+	z      func(x, y float64) float64
+}
+
+func (g nonUniformGrid) Dims() (c, r int)   { return len(g.xs), len(g.ys) }
+func (g nonUniformGrid) Z(c, r int) float64 { return g.z(g.xs[c], g.ys[r]) }
+func (g nonUniformGrid) X(c int) float64    { return g.xs[c] }
+func (g nonUniformGrid) Y(r int) float64    { return g.ys[r] }
+
+// ExampleHeatMap_rasterized draws a heat map of a non-uniform
+// grid as a single image with bicubic interpolation, and
+// fills a missing value with a NaN color.
+func ExampleHeatMap_rasterized() {
+	m := nonUniformGrid{
+		xs: []float64{0, 1, 2, 4, 7, 11},
+		ys: []float64{0, 1, 3, 6, 10},
+		z: func(x, y float64) float64 {
+			if x == 7 && y == 6 {
+				return math.NaN()
+			}
+			return math.Sin(x/3) * math.Cos(y/4)
+		},
+	}
+	h := NewHeatMap(m, palette.Heat(24, 1))
+	h.Rasterize = true
+	h.Interpolation = InterpolateBicubic
+	h.NaN = color.Gray{128}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Rasterized heat map"
+	p.Add(h)
+	p.X.Padding = 0
+	p.Y.Padding = 0
+
+	err = p.Save(200, 200, "testdata/heatMapRasterized.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestHeatMapRasterized(t *testing.T) {
+	checkPlot(ExampleHeatMap_rasterized, t, "heatMapRasterized.png")
+}
+
+// ExampleHeatMap_annotated draws a heat map with the value
+// of each element printed in a contrasting color.
+func ExampleHeatMap_annotated() {
+	m := offsetUnitGrid{
+		Data: mat64.NewDense(3, 4, []float64{
+			1, 2, 3, 4,
+			5, math.NaN(), 7, 8,
+			9, 10, 11, 12,
+		})}
+	h := NewHeatMap(m, palette.Heat(12, 1))
+	h.NaN = color.Gray{200}
+	err := h.EnableAnnotations("%.0f")
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Annotated heat map"
+	p.Add(h)
+	p.X.Padding = 0
+	p.Y.Padding = 0
+
+	err = p.Save(200, 150, "testdata/heatMapAnnotated.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestHeatMapAnnotated(t *testing.T) {
+	checkPlot(ExampleHeatMap_annotated, t, "heatMapAnnotated.png")
+}
+
+func TestInterpolate(t *testing.T) {
+	// zs holds the linear function z = x + 4y on a 4×4 grid.
+	zs := []float64{
+		0, 1, 2, 3,
+		4, 5, 6, 7,
+		8, 9, 10, 11,
+		12, 13, 14, 15,
+	}
+	for _, test := range []struct {
+		i, j    int
+		tx, ty  float64
+		bicubic bool
+		want    float64
+	}{
+		{i: 0, j: 0, tx: 0.5, ty: 0.5, want: 2.5},
+		{i: 1, j: 0, tx: 0.25, ty: 0, want: 1.25},
+		{i: 3, j: 3, tx: 0, ty: 0, want: 15},
+		// Catmull-Rom splines reproduce linear data away from the edges.
+		{i: 1, j: 1, tx: 0.5, ty: 0.5, bicubic: true, want: 7.5},
+		{i: 1, j: 1, tx: 0.25, ty: 0.75, bicubic: true, want: 8.25},
+		{i: 3, j: 3, tx: 0, ty: 0, bicubic: true, want: 15},
+	} {
+		got := interpolate(zs, 4, 4, test.i, test.j, test.tx, test.ty, 0, test.bicubic)
+		if math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("unexpected interpolation at (%d+%v, %d+%v) bicubic=%t: got:%v want:%v",
+				test.i, test.tx, test.j, test.ty, test.bicubic, got, test.want)
+		}
+	}
+
+	if c := contrasting(color.Black); c != color.White {
+		t.Errorf("unexpected contrasting color for black: got:%v", c)
+	}
+	if c := contrasting(color.RGBA{R: 255, G: 255, B: 200, A: 255}); c != color.Black {
+		t.Errorf("unexpected contrasting color for pale yellow: got:%v", c)
+	}
+}
+
+func TestHeatMapRasterDirection(t *testing.T) {
+	pal := palette.Heat(2, 1)
+	for _, test := range []struct {
+		name   string
+		xs     []float64
+		invert bool
+		left   color.Color
+	}{
+		{name: "ascending", xs: []float64{0, 1}, left: pal.Colors()[0]},
+		{name: "descending", xs: []float64{1, 0}, left: pal.Colors()[0]},
+		{name: "inverted", xs: []float64{0, 1}, invert: true, left: pal.Colors()[1]},
+	} {
+		g := nonUniformGrid{
+			xs: test.xs,
+			ys: []float64{0, 1},
+			z:  func(x, _ float64) float64 { return x },
+		}
+		h := NewHeatMap(g, pal)
+		h.Rasterize = true
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Add(h)
+		if test.invert {
+			p.X.Scale = plot.InvertedScale{Normalizer: plot.LinearScale{}}
+		}
+
+		var r recorder.Canvas
+		h.Plot(draw.Canvas{
+			Canvas:    &r,
+			Rectangle: vg.Rectangle{Max: vg.Point{X: 100, Y: 100}},
+		}, p)
+		var img image.Image
+		for _, a := range r.Actions {
+			if d, ok := a.(*recorder.DrawImage); ok {
+				img = d.Image
+			}
+		}
+		if img == nil {
+			t.Errorf("no image drawn for %s heat map", test.name)
+			continue
+		}
+		b := img.Bounds()
+		if b.Dx() < 90 {
+			t.Errorf("unexpected image width for %s heat map: got:%d", test.name, b.Dx())
+		}
+		if !sameColor(img.At(b.Min.X, b.Min.Y), test.left) {
+			t.Errorf("unexpected left color for %s heat map: got:%v want:%v", test.name, img.At(b.Min.X, b.Min.Y), test.left)
+		}
+	}
+}
+
+func TestHeatMapAnnotationDefaultFormat(t *testing.T) {
+	m := offsetUnitGrid{Data: mat64.NewDense(2, 2, []float64{1.5, 2, 3, 4})}
+	h := NewHeatMap(m, palette.Heat(2, 1))
+	if err := h.EnableAnnotations(""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	h.AnnotationFormat = ""
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(h)
+
+	var r recorder.Canvas
+	h.Plot(draw.Canvas{
+		Canvas:    &r,
+		Rectangle: vg.Rectangle{Max: vg.Point{X: 100, Y: 100}},
+	}, p)
+	var got []string
+	for _, a := range r.Actions {
+		if s, ok := a.(*recorder.FillString); ok {
+			got = append(got, s.String)
+		}
+	}
+	want := []string{"1.5", "3", "2", "4"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected annotations: got:%q want:%q", got, want)
+	}
+}
+
+// sameColor returns whether a and b are the same color.
+func sameColor(a, b color.Color) bool {
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	return ar == br && ag == bg && ab == bb && aa == ba
+}
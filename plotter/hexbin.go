@@ -0,0 +1,302 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Hexbin implements the Plotter interface, drawing a
+// two-dimensional histogram of a set of points using a
+// grid of hexagonal cells. Each non-empty cell is filled
+// with a palette color according to its value.
+type Hexbin struct {
+	// Cells holds the non-empty cells of the grid.
+	Cells []HexCell
+
+	// Width and Height are the horizontal and vertical
+	// distances between the centers of neighboring
+	// cells in the same row and column, in data units.
+	Width, Height float64
+
+	// Palette is the color palette used to render
+	// the cells. Palette must not be nil or return
+	// a zero length []color.Color.
+	Palette palette.Palette
+
+	// Underflow and Overflow are colors used to fill
+	// cells with values outside the dynamic range
+	// defined by Min and Max.
+	Underflow color.Color
+	Overflow  color.Color
+
+	// Min and Max define the dynamic range of the
+	// cell values.
+	Min, Max float64
+
+	// Log specifies that the palette is scaled
+	// logarithmically across the dynamic range.
+	// Cells with non-positive values are treated
+	// as underflowing when Log is true, and the
+	// scale starts at the smallest positive value
+	// if Min is not positive.
+	Log bool
+
+	// MinCount is the minimum number of points
+	// a cell must hold to be drawn.
+	MinCount int
+
+	// LineStyle is the style of the outline of each
+	// cell. No outline is drawn if the line width
+	// is zero.
+	LineStyle draw.LineStyle
+}
+
+// HexCell is a single cell of a Hexbin.
+type HexCell struct {
+	// X and Y are the location of the center of the cell.
+	X, Y float64
+
+	// Count is the number of points in the cell.
+	Count int
+
+	// Value is the value of the cell. It is equal to
+	// Count unless the Hexbin was created with a set
+	// of values to reduce.
+	Value float64
+}
+
+// Reduction reduces a set of values to a single value.
+type Reduction func([]float64) float64
+
+// ReduceMean returns the mean of vs.
+func ReduceMean(vs []float64) float64 {
+	return ReduceSum(vs) / float64(len(vs))
+}
+
+// ReduceSum returns the sum of vs.
+func ReduceSum(vs []float64) float64 {
+	var sum float64
+	for _, v := range vs {
+		sum += v
+	}
+	return sum
+}
+
+// NewHexbin returns a new Hexbin counting the points in
+// xy. The number of cells across the range of the data in
+// the X direction is given by n, and the number of rows is
+// chosen so that the cells are regular hexagons when the
+// plot has an equal aspect. Min and Max are set to the
+// range of the counts and MinCount is 1.
+func NewHexbin(xy XYer, n int, p palette.Palette) (*Hexbin, error) {
+	return newHexbin(xy, nil, nil, n, p)
+}
+
+// NewHexbinValues returns a new Hexbin as for NewHexbin,
+// except that the value of each cell is found by applying
+// reduce to the values in vs corresponding to the points
+// in the cell. If reduce is nil, ReduceMean is used.
+func NewHexbinValues(xy XYer, vs Valuer, reduce Reduction, n int, p palette.Palette) (*Hexbin, error) {
+	if vs.Len() != xy.Len() {
+		return nil, errors.New("Number of points does not match the number of values")
+	}
+	if reduce == nil {
+		reduce = ReduceMean
+	}
+	return newHexbin(xy, vs, reduce, n, p)
+}
+
+func newHexbin(xy XYer, vs Valuer, reduce Reduction, n int, p palette.Palette) (*Hexbin, error) {
+	if n <= 0 {
+		return nil, errors.New("Hexbin with non-positive number of cells")
+	}
+	data, err := CopyXYs(xy)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrNoData
+	}
+	var vals Values
+	if vs != nil {
+		vals, err = CopyValues(vs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	xmin, xmax, ymin, ymax := XYRange(data)
+	xmin, xmax = widenRange(xmin, xmax)
+	ymin, ymax = widenRange(ymin, ymax)
+
+	// The cells lie on two interleaved rectangular
+	// lattices offset by half a cell in each direction.
+	rows := int(float64(n) / math.Sqrt(3))
+	if rows < 1 {
+		rows = 1
+	}
+	h := &Hexbin{
+		Width:    (xmax - xmin) / float64(n),
+		Height:   (ymax - ymin) / float64(rows),
+		Palette:  p,
+		MinCount: 1,
+	}
+
+	type key struct {
+		i, j int
+		odd  bool
+	}
+	cells := make(map[key]int)
+	var members [][]float64
+	for k, d := range data {
+		x := (d.X - xmin) / h.Width
+		y := (d.Y - ymin) / h.Height
+
+		i1, j1 := math.Floor(x+0.5), math.Floor(y+0.5)
+		i2, j2 := math.Floor(x), math.Floor(y)
+		d1 := sq(x-i1) + 3*sq(y-j1)
+		d2 := sq(x-i2-0.5) + 3*sq(y-j2-0.5)
+
+		ck := key{i: int(i1), j: int(j1)}
+		if d2 < d1 {
+			ck = key{i: int(i2), j: int(j2), odd: true}
+		}
+		idx, ok := cells[ck]
+		if !ok {
+			idx = len(h.Cells)
+			cells[ck] = idx
+			cx, cy := float64(ck.i), float64(ck.j)
+			if ck.odd {
+				cx += 0.5
+				cy += 0.5
+			}
+			h.Cells = append(h.Cells, HexCell{
+				X: xmin + cx*h.Width,
+				Y: ymin + cy*h.Height,
+			})
+			members = append(members, nil)
+		}
+		h.Cells[idx].Count++
+		if vals != nil {
+			members[idx] = append(members[idx], vals[k])
+		}
+	}
+
+	h.Min, h.Max = math.Inf(1), math.Inf(-1)
+	for i := range h.Cells {
+		cell := &h.Cells[i]
+		if vals == nil {
+			cell.Value = float64(cell.Count)
+		} else {
+			cell.Value = reduce(members[i])
+		}
+		h.Min = math.Min(h.Min, cell.Value)
+		h.Max = math.Max(h.Max, cell.Value)
+	}
+
+	// Sort the cells so drawing order is deterministic.
+	sort.Sort(byCellPosition(h.Cells))
+
+	return h, nil
+}
+
+func sq(x float64) float64 { return x * x }
+
+type byCellPosition []HexCell
+
+func (c byCellPosition) Len() int { return len(c) }
+func (c byCellPosition) Less(i, j int) bool {
+	if c[i].Y != c[j].Y {
+		return c[i].Y < c[j].Y
+	}
+	return c[i].X < c[j].X
+}
+func (c byCellPosition) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+
+// hexagon holds the offsets of the vertices of a cell from
+// its center in units of the cell Width and Height.
+var hexagon = [6]struct{ X, Y float64 }{
+	{0.5, -1.0 / 6}, {0.5, 1.0 / 6}, {0, 1.0 / 3},
+	{-0.5, 1.0 / 6}, {-0.5, -1.0 / 6}, {0, -1.0 / 3},
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (h *Hexbin) Plot(c draw.Canvas, plt *plot.Plot) {
+	pal := h.Palette.Colors()
+	if len(pal) == 0 {
+		panic("hexbin: empty palette")
+	}
+
+	trX, trY := plt.Transforms(&c)
+
+	min, max := h.Min, h.Max
+	if h.Log {
+		// Non-positive values underflow, so the scale
+		// starts at the smallest positive value.
+		if min <= 0 {
+			min = math.Inf(1)
+			for _, cell := range h.Cells {
+				if cell.Value > 0 {
+					min = math.Min(min, cell.Value)
+				}
+			}
+		}
+		min, max = math.Log(min), math.Log(max)
+	}
+
+	pts := make([]vg.Point, len(hexagon))
+	for _, cell := range h.Cells {
+		if cell.Count < h.MinCount {
+			continue
+		}
+		v := cell.Value
+		var col color.Color
+		switch {
+		case h.Log && v <= 0:
+			col = h.Underflow
+		case h.Log:
+			col = paletteColor(pal, math.Log(v), min, max, h.Underflow, h.Overflow)
+		default:
+			col = paletteColor(pal, v, min, max, h.Underflow, h.Overflow)
+		}
+		if col == nil {
+			continue
+		}
+
+		for i, o := range hexagon {
+			pts[i] = vg.Point{
+				X: trX(cell.X + o.X*h.Width),
+				Y: trY(cell.Y + o.Y*h.Height),
+			}
+		}
+		c.FillPolygon(col, c.ClipPolygonXY(pts))
+		if h.LineStyle.Width != 0 {
+			c.StrokeLines(h.LineStyle, c.ClipLinesXY(append(pts, pts[0]))...)
+		}
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (h *Hexbin) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	for _, cell := range h.Cells {
+		xmin = math.Min(xmin, cell.X-h.Width/2)
+		xmax = math.Max(xmax, cell.X+h.Width/2)
+		ymin = math.Min(ymin, cell.Y-h.Height/3)
+		ymax = math.Max(ymax, cell.Y+h.Height/3)
+	}
+	return xmin, xmax, ymin, ymax
+}
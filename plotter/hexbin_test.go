@@ -0,0 +1,117 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/vgimg"
+)
+
+// ExampleHexbin draws hexagonal bins of normally distributed
+// points counted with a logarithmic color scale, and the
+// mean of a value associated with each point.
+func ExampleHexbin() {
+	rnd := rand.New(rand.NewSource(1))
+
+	pts := make(XYs, 10000)
+	vals := make(Values, len(pts))
+	for i := range pts {
+		pts[i].X = rnd.NormFloat64()
+		pts[i].Y = pts[i].X + rnd.NormFloat64()
+		vals[i] = pts[i].X * pts[i].Y
+	}
+
+	counts, err := NewHexbin(pts, 20, palette.Heat(12, 1))
+	if err != nil {
+		log.Panic(err)
+	}
+	counts.Log = true
+	counts.MinCount = 2
+
+	means, err := NewHexbinValues(pts, vals, ReduceMean, 20, palette.Rainbow(12, palette.Blue, palette.Red, 1, 1, 1))
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Log counts"
+	p.Add(counts)
+	err = p.Save(200, 200, "testdata/hexbinCounts.png")
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err = plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Mean of X×Y"
+	p.Add(means)
+	err = p.Save(200, 200, "testdata/hexbinMeans.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestHexbin(t *testing.T) {
+	checkPlot(ExampleHexbin, t, "hexbinCounts.png", "hexbinMeans.png")
+}
+
+func TestHexbinCounts(t *testing.T) {
+	pts := XYs{{0, 0}, {0.05, 0}, {1, 1}, {4, 4}}
+	vals := Values{1, 3, 5, 7}
+	h, err := NewHexbinValues(pts, vals, ReduceSum, 4, palette.Heat(3, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(h.Cells) != 3 {
+		t.Fatalf("unexpected number of cells: got:%d want:3", len(h.Cells))
+	}
+	first := h.Cells[0]
+	if first.Count != 2 || first.Value != 4 {
+		t.Errorf("unexpected first cell: got:%+v want count 2 and value 4", first)
+	}
+	var total int
+	for _, c := range h.Cells {
+		total += c.Count
+	}
+	if total != len(pts) {
+		t.Errorf("unexpected total count: got:%d want:%d", total, len(pts))
+	}
+}
+
+func TestHexbinLogNonPositive(t *testing.T) {
+	pts := XYs{{0, 0}, {1, 1}, {2, 2}, {3, 3}}
+	vals := Values{0, 1, 2, 3}
+	h, err := NewHexbinValues(pts, vals, ReduceMean, 4, palette.Heat(3, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	h.Log = true
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(h)
+	p.Draw(draw.New(vgimg.New(100, 100)))
+
+	pal := palette.Heat(3, 1).Colors()
+	for _, r := range [][2]float64{{math.Inf(-1), 1}, {0, math.NaN()}} {
+		if col := paletteColor(pal, 0.5, r[0], r[1], nil, nil); col != nil {
+			t.Errorf("unexpected color for range %v: got:%v want:nil", r, col)
+		}
+	}
+}
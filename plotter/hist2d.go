@@ -0,0 +1,224 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Hist2D implements the Plotter interface, drawing a
+// two-dimensional histogram of a set of points. Points
+// are counted in a rectangular grid of bins and each bin
+// is filled with a palette color according to its count.
+//
+// Hist2D also implements the GridXYZ interface, so the
+// binned counts may be used by other grid plotters such
+// as Contour.
+type Hist2D struct {
+	// Counts holds the number of points in each bin
+	// in row major order, so the count for column c
+	// and row r is held in Counts[r*Cols+c].
+	Counts []float64
+
+	// Cols and Rows are the number of bins in the X
+	// and Y directions respectively.
+	Cols, Rows int
+
+	// XMin, XMax, YMin and YMax describe the extent
+	// of the binned area.
+	XMin, XMax, YMin, YMax float64
+
+	// Palette is the color palette used to render
+	// the bins. Palette must not be nil or return
+	// a zero length []color.Color.
+	Palette palette.Palette
+
+	// Underflow and Overflow are colors used to fill
+	// bins with counts outside the dynamic range
+	// defined by Min and Max.
+	Underflow color.Color
+	Overflow  color.Color
+
+	// Min and Max define the dynamic range of the
+	// histogram. Empty bins are never drawn.
+	Min, Max float64
+
+	// LineStyle is the style of the outline of each
+	// non-empty bin. No outline is drawn if the
+	// line width is zero.
+	LineStyle draw.LineStyle
+}
+
+// NewHist2D returns a new two-dimensional histogram of the
+// points in xy binned into a grid with the given number of
+// columns and rows. The bins cover the range of the data.
+// Min and Max are set to the smallest and largest non-zero
+// bin counts.
+func NewHist2D(xy XYer, cols, rows int, p palette.Palette) (*Hist2D, error) {
+	if cols <= 0 || rows <= 0 {
+		return nil, errors.New("Hist2D with non-positive number of bins")
+	}
+	data, err := CopyXYs(xy)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrNoData
+	}
+
+	h := &Hist2D{
+		Counts:  make([]float64, cols*rows),
+		Cols:    cols,
+		Rows:    rows,
+		Palette: p,
+	}
+	h.XMin, h.XMax, h.YMin, h.YMax = XYRange(data)
+	h.XMin, h.XMax = widenRange(h.XMin, h.XMax)
+	h.YMin, h.YMax = widenRange(h.YMin, h.YMax)
+
+	for _, d := range data {
+		c := binIndex(d.X, h.XMin, h.XMax, cols)
+		r := binIndex(d.Y, h.YMin, h.YMax, rows)
+		h.Counts[r*cols+c]++
+	}
+
+	h.Min, h.Max = math.Inf(1), math.Inf(-1)
+	for _, n := range h.Counts {
+		if n == 0 {
+			continue
+		}
+		h.Min = math.Min(h.Min, n)
+		h.Max = math.Max(h.Max, n)
+	}
+
+	return h, nil
+}
+
+// widenRange returns a unit range centered on min if min
+// and max are equal, otherwise it returns min and max.
+func widenRange(min, max float64) (float64, float64) {
+	if min == max {
+		return min - 0.5, max + 0.5
+	}
+	return min, max
+}
+
+// binIndex returns the index of the bin holding v when
+// the range min to max is divided into n equal bins.
+func binIndex(v, min, max float64, n int) int {
+	i := int((v - min) / (max - min) * float64(n))
+	if i >= n {
+		i = n - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	return i
+}
+
+// Dims implements the Dims method of the GridXYZ interface.
+func (h *Hist2D) Dims() (c, r int) { return h.Cols, h.Rows }
+
+// Z implements the Z method of the GridXYZ interface,
+// returning the count in the bin at column c and row r.
+func (h *Hist2D) Z(c, r int) float64 {
+	if c < 0 || c >= h.Cols || r < 0 || r >= h.Rows {
+		panic("index out of range")
+	}
+	return h.Counts[r*h.Cols+c]
+}
+
+// X implements the X method of the GridXYZ interface,
+// returning the center of the bins in column c.
+func (h *Hist2D) X(c int) float64 {
+	if c < 0 || c >= h.Cols {
+		panic("index out of range")
+	}
+	w := (h.XMax - h.XMin) / float64(h.Cols)
+	return h.XMin + (float64(c)+0.5)*w
+}
+
+// Y implements the Y method of the GridXYZ interface,
+// returning the center of the bins in row r.
+func (h *Hist2D) Y(r int) float64 {
+	if r < 0 || r >= h.Rows {
+		panic("index out of range")
+	}
+	w := (h.YMax - h.YMin) / float64(h.Rows)
+	return h.YMin + (float64(r)+0.5)*w
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (h *Hist2D) Plot(c draw.Canvas, plt *plot.Plot) {
+	pal := h.Palette.Colors()
+	if len(pal) == 0 {
+		panic("hist2d: empty palette")
+	}
+
+	trX, trY := plt.Transforms(&c)
+
+	dx := (h.XMax - h.XMin) / float64(h.Cols)
+	dy := (h.YMax - h.YMin) / float64(h.Rows)
+	for j := 0; j < h.Rows; j++ {
+		for i := 0; i < h.Cols; i++ {
+			v := h.Counts[j*h.Cols+i]
+			if v == 0 {
+				continue
+			}
+			col := paletteColor(pal, v, h.Min, h.Max, h.Underflow, h.Overflow)
+			if col == nil {
+				continue
+			}
+
+			xmin, ymin := trX(h.XMin+float64(i)*dx), trY(h.YMin+float64(j)*dy)
+			xmax, ymax := trX(h.XMin+float64(i+1)*dx), trY(h.YMin+float64(j+1)*dy)
+			pts := []vg.Point{
+				{xmin, ymin},
+				{xmax, ymin},
+				{xmax, ymax},
+				{xmin, ymax},
+			}
+			c.FillPolygon(col, c.ClipPolygonXY(pts))
+			if h.LineStyle.Width != 0 {
+				pts = append(pts, pts[0])
+				c.StrokeLines(h.LineStyle, c.ClipLinesXY(pts)...)
+			}
+		}
+	}
+}
+
+// paletteColor returns the color in pal corresponding to v
+// when pal is scaled uniformly across the range min to max.
+// The under and over colors are returned for values outside
+// the range, and nil is returned if v is NaN or the range is
+// not finite.
+func paletteColor(pal []color.Color, v, min, max float64, under, over color.Color) color.Color {
+	switch {
+	case math.IsNaN(v), math.IsNaN(min), math.IsNaN(max), math.IsInf(min, 0), math.IsInf(max, 0):
+		return nil
+	case v < min:
+		return under
+	case v > max:
+		return over
+	case min == max:
+		return pal[len(pal)-1]
+	}
+	// ps scales the palette uniformly across the data range.
+	ps := float64(len(pal)-1) / (max - min)
+	return pal[int((v-min)*ps+0.5)]
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (h *Hist2D) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return h.XMin, h.XMax, h.YMin, h.YMax
+}
@@ -0,0 +1,69 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+)
+
+// ExampleHist2D draws a two-dimensional histogram
+// of normally distributed points.
+func ExampleHist2D() {
+	rnd := rand.New(rand.NewSource(1))
+
+	pts := make(XYs, 10000)
+	for i := range pts {
+		pts[i].X = rnd.NormFloat64()
+		pts[i].Y = pts[i].X + rnd.NormFloat64()
+	}
+
+	h, err := NewHist2D(pts, 20, 20, palette.Heat(12, 1))
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "2D histogram"
+	p.Add(h)
+
+	err = p.Save(200, 200, "testdata/hist2D.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestHist2D(t *testing.T) {
+	checkPlot(ExampleHist2D, t, "hist2D.png")
+}
+
+func TestHist2DCounts(t *testing.T) {
+	pts := XYs{{0, 0}, {0.1, 0.1}, {1, 0}, {2, 2}, {1.9, 2}, {2, 1.9}}
+	h, err := NewHist2D(pts, 2, 2, palette.Heat(3, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []float64{
+		2, 1,
+		0, 3,
+	}
+	if !reflect.DeepEqual(h.Counts, want) {
+		t.Errorf("unexpected counts: got:%v want:%v", h.Counts, want)
+	}
+	if h.Min != 1 || h.Max != 3 {
+		t.Errorf("unexpected range: got:[%v,%v] want:[1,3]", h.Min, h.Max)
+	}
+	if x, y := h.X(1), h.Y(0); x != 1.5 || y != 0.5 {
+		t.Errorf("unexpected bin center: got:(%v,%v) want:(1.5,0.5)", x, y)
+	}
+}
@@ -6,6 +6,7 @@ package plotter
 
 import (
 	"image/color"
+	"math"
 
 	"github.com/gonum/plot"
 	"github.com/gonum/plot/vg"
@@ -23,6 +24,35 @@ type Line struct {
 
 	// ShadeColor is the color of the shaded area.
 	ShadeColor *color.Color
+
+	// Decimation specifies how the points are
+	// reduced before drawing. By default every
+	// point is drawn. Decimation assumes that
+	// the points are ordered by X.
+	Decimation Decimation
+
+	// Smoothing specifies how the line is
+	// interpolated between the points. By
+	// default straight segments are drawn.
+	Smoothing Smoothing
+
+	// YErrors, if not nil, holds the errors of the
+	// Y values of the points. They are set by
+	// SetYErrors.
+	YErrors YErrors
+
+	// ErrorStyle is the style of the error bars. If
+	// its color is nil, the color of LineStyle is used.
+	ErrorStyle draw.LineStyle
+
+	// CapWidth is the width of the caps of
+	// the error bars.
+	CapWidth vg.Length
+
+	// ErrorBand, if not nil, is the color of a band
+	// spanning the errors, drawn behind the line
+	// instead of error bars.
+	ErrorBand color.Color
 }
 
 // NewLine returns a Line that uses the default line style and
@@ -33,31 +63,153 @@ func NewLine(xys XYer) (*Line, error) {
 		return nil, err
 	}
 	return &Line{
-		XYs:       data,
-		LineStyle: DefaultLineStyle,
+		XYs:        data,
+		LineStyle:  DefaultLineStyle,
+		ErrorStyle: draw.LineStyle{Width: DefaultLineStyle.Width},
+		CapWidth:   DefaultCapWidth,
+	}, nil
+}
+
+// NewLineWithGaps returns a Line that uses the default line
+// style and does not draw glyphs. Points with a NaN x or y value
+// are accepted and break the line into separate segments.
+func NewLineWithGaps(xys XYer) (*Line, error) {
+	data, err := CopyXYsWithGaps(xys)
+	if err != nil {
+		return nil, err
+	}
+	return &Line{
+		XYs:        data,
+		LineStyle:  DefaultLineStyle,
+		ErrorStyle: draw.LineStyle{Width: DefaultLineStyle.Width},
+		CapWidth:   DefaultCapWidth,
 	}, nil
 }
 
+// SetYErrors sets the errors of the Y values of the line to
+// a copy of those of e, which are drawn as error bars at the
+// points, or as a band if ErrorBand is set. The errors are
+// interpreted in the same way as by NewYErrorBars. The number
+// of errors must match the number of points.
+func (pts *Line) SetYErrors(e interface {
+	YErrorer
+	Len() int
+}) error {
+	errs, err := copyYErrors(e, len(pts.XYs))
+	if err != nil {
+		return err
+	}
+	pts.YErrors = errs
+	return nil
+}
+
 // Plot draws the Line, implementing the plot.Plotter
-// interface.
+// interface. Points with a NaN x or y value break the
+// line into separate segments.
 func (pts *Line) Plot(c draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&c)
-	ps := make([]vg.Point, len(pts.XYs))
+	if pts.YErrors != nil && pts.ErrorBand != nil {
+		pts.plotErrorBand(c, trX, trY)
+	}
+	for _, seg := range pts.segments() {
+		pts.plotSegment(c, plt, trX, trY, seg)
+	}
+	if pts.YErrors != nil && pts.ErrorBand == nil {
+		sty := pts.ErrorStyle
+		if sty.Color == nil {
+			sty.Color = pts.LineStyle.Color
+		}
+		for i, p := range pts.XYs {
+			if missing(p.X, p.Y) {
+				continue
+			}
+			lo, hi := pts.errorRange(i)
+			drawErrorBar(&c, sty, pts.CapWidth, trX(p.X), trY(lo), trY(hi), false)
+		}
+	}
+}
+
+// errorRange returns the ends of the error
+// range of the ith point.
+func (pts *Line) errorRange(i int) (lo, hi float64) {
+	y := pts.XYs[i].Y
+	return y - math.Abs(pts.YErrors[i].Low), y + math.Abs(pts.YErrors[i].High)
+}
 
+// plotErrorBand fills the error band of each unbroken
+// segment of the Line.
+func (pts *Line) plotErrorBand(c draw.Canvas, trX, trY func(float64) vg.Length) {
+	var upper, lower []vg.Point
+	fill := func() {
+		if len(upper) > 1 {
+			for i := len(lower) - 1; i >= 0; i-- {
+				upper = append(upper, lower[i])
+			}
+			c.FillPolygon(pts.ErrorBand, c.ClipPolygonXY(upper))
+		}
+		upper, lower = nil, nil
+	}
 	for i, p := range pts.XYs {
+		if missing(p.X, p.Y) {
+			fill()
+			continue
+		}
+		lo, hi := pts.errorRange(i)
+		x := trX(p.X)
+		upper = append(upper, vg.Point{X: x, Y: trY(hi)})
+		lower = append(lower, vg.Point{X: x, Y: trY(lo)})
+	}
+	fill()
+}
+
+// segments returns the unbroken segments of the Line
+// between points with a NaN x or y value.
+func (pts *Line) segments() []XYs {
+	var segs []XYs
+	for start := 0; start < len(pts.XYs); {
+		if missing(pts.XYs[start].X, pts.XYs[start].Y) {
+			start++
+			continue
+		}
+		end := start + 1
+		for end < len(pts.XYs) && !missing(pts.XYs[end].X, pts.XYs[end].Y) {
+			end++
+		}
+		segs = append(segs, pts.XYs[start:end])
+		start = end
+	}
+	return segs
+}
+
+// plotSegment draws a single unbroken segment of the Line.
+func (pts *Line) plotSegment(c draw.Canvas, plt *plot.Plot, trX, trY func(float64) vg.Length, seg XYs) {
+	seg = smooth(seg, pts.Smoothing, func(i int) int {
+		p := vg.Point{X: trX(seg[i].X), Y: trY(seg[i].Y)}
+		q := vg.Point{X: trX(seg[i+1].X), Y: trY(seg[i+1].Y)}
+		return int(math.Ceil(float64(distance(p, q) / smoothStep)))
+	})
+	ps := make([]vg.Point, len(seg))
+	for i, p := range seg {
 		ps[i].X = trX(p.X)
 		ps[i].Y = trY(p.Y)
 	}
+	if pts.Decimation != NoDecimation {
+		idx := decimate(c, ps, pts.Decimation)
+		for i, j := range idx {
+			ps[i] = ps[j]
+		}
+		ps = ps[:len(idx)]
+	}
 
-	if pts.ShadeColor != nil && len(ps) > 0 {
+	if pts.ShadeColor != nil {
 		c.SetColor(*pts.ShadeColor)
 		minY := trY(plt.Y.Min)
 		var pa vg.Path
 		pa.Move(vg.Point{X: ps[0].X, Y: minY})
-		for i := range pts.XYs {
+		for i := range ps {
 			pa.Line(ps[i])
 		}
-		pa.Line(vg.Point{X: ps[len(pts.XYs)-1].X, Y: minY})
+		pa.Line(vg.Point{X: ps[len(ps)-1].X, Y: minY})
 		pa.Close()
 		c.Fill(pa)
 	}
@@ -67,9 +219,28 @@ func (pts *Line) Plot(c draw.Canvas, plt *plot.Plot) {
 
 // DataRange returns the minimum and maximum
 // x and y values, implementing the plot.DataRanger
-// interface.
+// interface. The range includes any overshoot of
+// the smoothed line and any errors.
 func (pts *Line) DataRange() (xmin, xmax, ymin, ymax float64) {
-	return XYRange(pts)
+	xmin, xmax, ymin, ymax = gapRange(pts)
+	if pts.YErrors != nil {
+		for i, p := range pts.XYs {
+			if missing(p.X, p.Y) {
+				continue
+			}
+			lo, hi := pts.errorRange(i)
+			ymin, ymax = math.Min(ymin, lo), math.Max(ymax, hi)
+		}
+	}
+	if pts.Smoothing == NoSmoothing {
+		return xmin, xmax, ymin, ymax
+	}
+	for _, seg := range pts.segments() {
+		dense := smooth(seg, pts.Smoothing, func(int) int { return maxSmoothSamples })
+		_, _, lo, hi := XYRange(dense)
+		ymin, ymax = math.Min(ymin, lo), math.Max(ymax, hi)
+	}
+	return xmin, xmax, ymin, ymax
 }
 
 // Thumbnail the thumbnail for the Line,
@@ -100,8 +271,10 @@ func NewLinePoints(xys XYer) (*Line, *Scatter, error) {
 		return nil, nil, err
 	}
 	l := &Line{
-		XYs:       s.XYs,
-		LineStyle: DefaultLineStyle,
+		XYs:        s.XYs,
+		LineStyle:  DefaultLineStyle,
+		ErrorStyle: draw.LineStyle{Width: DefaultLineStyle.Width},
+		CapWidth:   DefaultCapWidth,
 	}
 	return l, s, nil
 }
@@ -0,0 +1,148 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"github.com/gonum/plot"
+)
+
+// ExampleLine_gaps draws a shaded line and scatter with
+// missing samples marked by NaN.
+func ExampleLine_gaps() {
+	pts := make(XYs, 60)
+	for i := range pts {
+		pts[i].X = float64(i) / 10
+		pts[i].Y = math.Sin(pts[i].X) + 1.5
+		if (i >= 15 && i < 22) || i == 40 || i == 41 {
+			pts[i].Y = math.NaN()
+		}
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Line with gaps"
+
+	l, err := NewLineWithGaps(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	var shade color.Color = color.Gray{Y: 220}
+	l.ShadeColor = &shade
+
+	s, err := NewScatterWithGaps(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Add(l, s)
+
+	err = p.Save(200, 200, "testdata/lineGaps.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestLineGaps(t *testing.T) {
+	checkPlot(ExampleLine_gaps, t, "lineGaps.png")
+}
+
+func TestCopyXYsWithGaps(t *testing.T) {
+	nan := math.NaN()
+	xys, err := CopyXYsWithGaps(XYs{{0, 1}, {nan, 2}, {2, nan}, {3, -1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xmin, xmax, ymin, ymax := gapRange(xys)
+	if xmin != 0 || xmax != 3 || ymin != -1 || ymax != 1 {
+		t.Errorf("unexpected range: got:(%v, %v, %v, %v) want:(0, 3, -1, 1)", xmin, xmax, ymin, ymax)
+	}
+
+	// The data ranges of plotters with gaps
+	// ignore the missing points.
+	l, err := NewLineWithGaps(xys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s, err := NewScatterWithGaps(xys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, dr := range []plot.DataRanger{l, s} {
+		xmin, xmax, ymin, ymax = dr.DataRange()
+		if xmin != 0 || xmax != 3 || ymin != -1 || ymax != 1 {
+			t.Errorf("unexpected data range of %T: got:(%v, %v, %v, %v) want:(0, 3, -1, 1)", dr, xmin, xmax, ymin, ymax)
+		}
+	}
+
+	_, err = CopyXYsWithGaps(XYs{{0, 1}, {math.Inf(1), 2}})
+	if err != ErrInfinity {
+		t.Errorf("unexpected error for infinite value: got:%v want:%v", err, ErrInfinity)
+	}
+	_, err = NewLine(XYs{{0, 1}, {nan, 2}})
+	if err != ErrNaN {
+		t.Errorf("unexpected error for NaN value: got:%v want:%v", err, ErrNaN)
+	}
+}
+
+// ExampleLine_errorBand draws a line with its
+// errors shown as a shaded band, and another
+// with error bars.
+func ExampleLine_errorBand() {
+	pts := make(XYs, 20)
+	errs := make(YErrors, len(pts))
+	bars := make(XYs, 8)
+	barErrs := make(YErrors, len(bars))
+	for i := range pts {
+		pts[i].X = float64(i) / 2
+		pts[i].Y = math.Sin(pts[i].X) + 3
+		errs[i].Low = 0.2 + 0.05*float64(i)
+		errs[i].High = errs[i].Low
+	}
+	for i := range bars {
+		bars[i].X = float64(i) * 1.25
+		bars[i].Y = math.Cos(bars[i].X)
+		barErrs[i].Low, barErrs[i].High = 0.3, 0.2
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Line with errors"
+
+	band, err := NewLine(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	if err = band.SetYErrors(errs); err != nil {
+		log.Panic(err)
+	}
+	band.ErrorBand = color.Gray{Y: 210}
+
+	l, err := NewLine(bars)
+	if err != nil {
+		log.Panic(err)
+	}
+	l.Color = color.RGBA{B: 255, A: 255}
+	if err = l.SetYErrors(barErrs); err != nil {
+		log.Panic(err)
+	}
+	p.Add(band, l)
+
+	err = p.Save(200, 200, "testdata/lineErrorBand.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestLineErrorBand(t *testing.T) {
+	checkPlot(ExampleLine_errorBand, t, "lineErrorBand.png")
+}
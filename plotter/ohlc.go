@@ -0,0 +1,336 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+var (
+	// DefaultUpColor is the default color for intervals
+	// where the close value is at least the open value.
+	DefaultUpColor = color.RGBA{G: 160, A: 255}
+
+	// DefaultDownColor is the default color for intervals
+	// where the close value is less than the open value.
+	DefaultDownColor = color.RGBA{R: 196, A: 255}
+)
+
+// ohlcPlot contains the shared fields for candlestick
+// and OHLC bar plots.
+type ohlcPlot struct {
+	// OHLCs is a copy of the intervals being plotted.
+	OHLCs
+
+	// UpColor and DownColor are the colors used to
+	// draw rising and falling intervals respectively.
+	UpColor, DownColor color.Color
+
+	// LineStyle is the style of the lines drawn for
+	// each interval. The color of the style is ignored
+	// in favor of UpColor and DownColor.
+	LineStyle draw.LineStyle
+
+	// Width is the width of each interval in drawing
+	// units. Width is only used if DataWidth is zero.
+	Width vg.Length
+
+	// DataWidth is the width of each interval in data
+	// units along the X axis. If DataWidth is zero,
+	// Width is used.
+	DataWidth float64
+
+	// Volumes holds optional volume values for each
+	// interval. If Volumes is not nil, it must have the
+	// same length as OHLCs and the volumes are drawn as
+	// bars along the bottom of the plot.
+	Volumes Values
+
+	// VolumeHeight is the height of the tallest volume
+	// bar as a fraction of the height of the plot.
+	VolumeHeight float64
+
+	// VolumeColor is the color used to fill volume bars.
+	// If VolumeColor is nil, the color of the
+	// corresponding interval is used.
+	VolumeColor color.Color
+}
+
+func newOHLCPlot(data OHLCer) (ohlcPlot, error) {
+	cpy, err := CopyOHLCs(data)
+	if err != nil {
+		return ohlcPlot{}, err
+	}
+	o := ohlcPlot{
+		OHLCs:        cpy,
+		UpColor:      DefaultUpColor,
+		DownColor:    DefaultDownColor,
+		LineStyle:    DefaultLineStyle,
+		Width:        vg.Points(5),
+		VolumeHeight: 0.2,
+	}
+
+	// Make intervals fill 60% of the smallest gap between
+	// their locations so the plot is readable irrespective
+	// of the units of the X axis, for example Unix time.
+	xs := make([]float64, len(cpy))
+	for i, d := range cpy {
+		xs[i] = d.X
+	}
+	sort.Float64s(xs)
+	gap := math.Inf(1)
+	for i := 1; i < len(xs); i++ {
+		if d := xs[i] - xs[i-1]; d > 0 && d < gap {
+			gap = d
+		}
+	}
+	if !math.IsInf(gap, 1) {
+		o.DataWidth = 0.6 * gap
+	}
+
+	return o, nil
+}
+
+// isUp returns whether the ith interval closed at or above its open.
+func (o *ohlcPlot) isUp(i int) bool {
+	return o.OHLCs[i].Close >= o.OHLCs[i].Open
+}
+
+// colorOf returns the color of the ith interval.
+func (o *ohlcPlot) colorOf(i int) color.Color {
+	if o.isUp(i) {
+		return o.UpColor
+	}
+	return o.DownColor
+}
+
+// extent returns the left and right drawing coordinates of
+// an interval located at x.
+func (o *ohlcPlot) extent(trX func(float64) vg.Length, x float64) (left, right vg.Length) {
+	if o.DataWidth != 0 {
+		return trX(x - o.DataWidth/2), trX(x + o.DataWidth/2)
+	}
+	mid := trX(x)
+	return mid - o.Width/2, mid + o.Width/2
+}
+
+// plotVolumes draws the volume bars along the bottom of c.
+func (o *ohlcPlot) plotVolumes(c draw.Canvas, plt *plot.Plot) {
+	if o.Volumes == nil {
+		return
+	}
+	if len(o.Volumes) != len(o.OHLCs) {
+		panic("ohlc: volume length mismatch")
+	}
+	trX, _ := plt.Transforms(&c)
+
+	var max float64
+	for _, v := range o.Volumes {
+		max = math.Max(max, v)
+	}
+	if max == 0 {
+		return
+	}
+	scale := vg.Length(o.VolumeHeight) * c.Size().Y / vg.Length(max)
+
+	for i, d := range o.OHLCs {
+		if !c.ContainsX(trX(d.X)) {
+			continue
+		}
+		col := o.VolumeColor
+		if col == nil {
+			col = o.colorOf(i)
+		}
+		left, right := o.extent(trX, d.X)
+		top := c.Min.Y + vg.Length(o.Volumes[i])*scale
+		c.FillPolygon(col, c.ClipPolygonX([]vg.Point{
+			{left, c.Min.Y},
+			{right, c.Min.Y},
+			{right, top},
+			{left, top},
+		}))
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (o *ohlcPlot) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	for _, d := range o.OHLCs {
+		xmin = math.Min(xmin, d.X-o.DataWidth/2)
+		xmax = math.Max(xmax, d.X+o.DataWidth/2)
+		ymin = math.Min(ymin, math.Min(d.Low, math.Min(d.Open, d.Close)))
+		ymax = math.Max(ymax, math.Max(d.High, math.Max(d.Open, d.Close)))
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface.
+// When the interval width is given in drawing units the
+// boxes ensure that the first and last intervals are not
+// clipped.
+func (o *ohlcPlot) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	half := o.LineStyle.Width / 2
+	if o.DataWidth == 0 {
+		half += o.Width / 2
+	}
+	boxes := make([]plot.GlyphBox, len(o.OHLCs))
+	for i, d := range o.OHLCs {
+		boxes[i].X = plt.X.Norm(d.X)
+		boxes[i].Rectangle = vg.Rectangle{
+			Min: vg.Point{X: -half},
+			Max: vg.Point{X: +half},
+		}
+	}
+	return boxes
+}
+
+// Candlestick implements the Plotter interface, drawing
+// a candlestick chart of open, high, low and close values.
+// Each interval is drawn as a body spanning the open and
+// close values, with wicks extending to the high and low
+// values.
+type Candlestick struct {
+	ohlcPlot
+
+	// Hollow specifies that the bodies of rising
+	// intervals are outlined rather than filled.
+	Hollow bool
+}
+
+// NewCandlestick returns a new Candlestick plotter for
+// the given data. If there is more than one interval,
+// DataWidth is set to 60% of the smallest distance between
+// interval locations, otherwise Width is used.
+func NewCandlestick(data OHLCer) (*Candlestick, error) {
+	o, err := newOHLCPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Candlestick{ohlcPlot: o}, nil
+}
+
+// Plot implements the plot.Plotter interface.
+func (cs *Candlestick) Plot(c draw.Canvas, plt *plot.Plot) {
+	cs.plotVolumes(c, plt)
+
+	trX, trY := plt.Transforms(&c)
+	for i, d := range cs.OHLCs {
+		x := trX(d.X)
+		if !c.ContainsX(x) {
+			continue
+		}
+		sty := cs.LineStyle
+		sty.Color = cs.colorOf(i)
+
+		left, right := cs.extent(trX, d.X)
+		top := trY(math.Max(d.Open, d.Close))
+		bottom := trY(math.Min(d.Open, d.Close))
+
+		wicks := c.ClipLinesY(
+			[]vg.Point{{x, trY(d.High)}, {x, top}},
+			[]vg.Point{{x, bottom}, {x, trY(d.Low)}},
+		)
+		c.StrokeLines(sty, wicks...)
+
+		body := []vg.Point{
+			{left, bottom},
+			{left, top},
+			{right, top},
+			{right, bottom},
+		}
+		if !cs.Hollow || !cs.isUp(i) {
+			c.FillPolygon(sty.Color, c.ClipPolygonY(body))
+		}
+		body = append(body, body[0])
+		c.StrokeLines(sty, c.ClipLinesY(body)...)
+	}
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (cs *Candlestick) Thumbnail(c *draw.Canvas) {
+	sty := cs.LineStyle
+	sty.Color = cs.UpColor
+
+	x := c.Center().X
+	w := c.Size().X / 4
+	h := c.Size().Y / 4
+	c.StrokeLine2(sty, x, c.Min.Y, x, c.Max.Y)
+	body := []vg.Point{
+		{x - w, c.Min.Y + h},
+		{x - w, c.Max.Y - h},
+		{x + w, c.Max.Y - h},
+		{x + w, c.Min.Y + h},
+	}
+	if !cs.Hollow {
+		c.FillPolygon(sty.Color, body)
+	}
+	c.StrokeLines(sty, append(body, body[0]))
+}
+
+// OHLCBars implements the Plotter interface, drawing
+// open, high, low and close values as bars. Each interval
+// is drawn as a vertical line from the low to the high
+// value, with a tick on the left marking the open value
+// and a tick on the right marking the close value.
+type OHLCBars struct {
+	ohlcPlot
+}
+
+// NewOHLCBars returns a new OHLCBars plotter for the given
+// data. The width of the ticks is set in the same way as
+// for NewCandlestick.
+func NewOHLCBars(data OHLCer) (*OHLCBars, error) {
+	o, err := newOHLCPlot(data)
+	if err != nil {
+		return nil, err
+	}
+	return &OHLCBars{ohlcPlot: o}, nil
+}
+
+// Plot implements the plot.Plotter interface.
+func (b *OHLCBars) Plot(c draw.Canvas, plt *plot.Plot) {
+	b.plotVolumes(c, plt)
+
+	trX, trY := plt.Transforms(&c)
+	for i, d := range b.OHLCs {
+		x := trX(d.X)
+		if !c.ContainsX(x) {
+			continue
+		}
+		sty := b.LineStyle
+		sty.Color = b.colorOf(i)
+
+		left, right := b.extent(trX, d.X)
+		open, close := trY(d.Open), trY(d.Close)
+		lines := c.ClipLinesY(
+			[]vg.Point{{x, trY(d.Low)}, {x, trY(d.High)}},
+			[]vg.Point{{left, open}, {x, open}},
+			[]vg.Point{{x, close}, {right, close}},
+		)
+		c.StrokeLines(sty, lines...)
+	}
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (b *OHLCBars) Thumbnail(c *draw.Canvas) {
+	sty := b.LineStyle
+	sty.Color = b.UpColor
+
+	x := c.Center().X
+	w := c.Size().X / 4
+	h := c.Size().Y / 4
+	c.StrokeLines(sty,
+		[]vg.Point{{x, c.Min.Y}, {x, c.Max.Y}},
+		[]vg.Point{{x - w, c.Min.Y + h}, {x, c.Min.Y + h}},
+		[]vg.Point{{x, c.Max.Y - h}, {x + w, c.Max.Y - h}},
+	)
+}
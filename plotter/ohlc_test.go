@@ -0,0 +1,130 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// randomOHLCs returns n daily intervals of a random walk
+// along with a volume for each interval.
+func randomOHLCs(n int) (OHLCs, Values) {
+	rnd := rand.New(rand.NewSource(1))
+	start := time.Date(2016, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	data := make(OHLCs, n)
+	vols := make(Values, n)
+	last := 100.0
+	for i := range data {
+		d := &data[i]
+		d.X = float64(start.AddDate(0, 0, i).Unix())
+		d.Open = last
+		d.Close = d.Open + 4*rnd.NormFloat64()
+		d.High = math.Max(d.Open, d.Close) + 2*rnd.Float64()
+		d.Low = math.Min(d.Open, d.Close) - 2*rnd.Float64()
+		last = d.Close
+		vols[i] = 1000 + 500*rnd.Float64()
+	}
+	return data, vols
+}
+
+// ExampleCandlestick draws a candlestick chart of daily
+// values against a time axis, with the volume of each day
+// shown along the bottom of the plot.
+func ExampleCandlestick() {
+	data, vols := randomOHLCs(20)
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Candlestick"
+	p.X.Tick.Marker = plot.UnixTimeTicks{Format: "Jan 02"}
+
+	cs, err := NewCandlestick(data)
+	if err != nil {
+		log.Panic(err)
+	}
+	cs.Hollow = true
+	cs.Volumes = vols
+	cs.VolumeColor = color.Gray{200}
+
+	p.Add(NewGrid(), cs)
+
+	err = p.Save(12*vg.Centimeter, 8*vg.Centimeter, "testdata/candlestick.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestCandlestick(t *testing.T) {
+	checkPlot(ExampleCandlestick, t, "candlestick.png")
+}
+
+// ExampleOHLCBars draws open, high, low and close bars
+// with a fixed width in drawing units.
+func ExampleOHLCBars() {
+	data, _ := randomOHLCs(20)
+	for i := range data {
+		data[i].X = float64(i)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "OHLC bars"
+
+	bars, err := NewOHLCBars(data)
+	if err != nil {
+		log.Panic(err)
+	}
+	bars.DataWidth = 0
+	bars.Width = vg.Points(6)
+
+	p.Add(bars)
+	p.Legend.Add("price", bars)
+
+	err = p.Save(12*vg.Centimeter, 8*vg.Centimeter, "testdata/ohlcBars.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestOHLCBars(t *testing.T) {
+	checkPlot(ExampleOHLCBars, t, "ohlcBars.png")
+}
+
+func TestOHLCDataRange(t *testing.T) {
+	data := OHLCs{
+		{X: 0, Open: 1, High: 3, Low: 0.5, Close: 2},
+		{X: 2, Open: 2, High: 2.5, Low: 1, Close: 1.5},
+		{X: 3, Open: 1.5, High: 4, Low: 1.5, Close: 3},
+	}
+	cs, err := NewCandlestick(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cs.DataWidth != 0.6 {
+		t.Errorf("unexpected data width: got:%v want:0.6", cs.DataWidth)
+	}
+	xmin, xmax, ymin, ymax := cs.DataRange()
+	if xmin != -0.3 || xmax != 3.3 || ymin != 0.5 || ymax != 4 {
+		t.Errorf("unexpected data range: got:[%v,%v]×[%v,%v] want:[-0.3,3.3]×[0.5,4]", xmin, xmax, ymin, ymax)
+	}
+
+	_, err = NewCandlestick(OHLCs{{X: 0, Open: math.NaN()}})
+	if err != ErrNaN {
+		t.Errorf("unexpected error for NaN data: got:%v want:%v", err, ErrNaN)
+	}
+}
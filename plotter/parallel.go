@@ -0,0 +1,212 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Tabler wraps the Dims and At methods of
+// a table of values.
+type Tabler interface {
+	// Dims returns the number of rows
+	// and columns in the table.
+	Dims() (r, c int)
+
+	// At returns the value in row i
+	// and column j of the table.
+	At(i, j int) float64
+}
+
+// ParallelCoordinates implements the Plotter interface, drawing
+// a parallel coordinates plot of a table. Each column of the
+// table is a dimension with its own vertical axis, located at
+// the X value equal to the index of the column, and each row is
+// drawn as a line across the axes.
+//
+// The axes of the dimensions are drawn by the plotter, so the
+// axes of the plot should normally be hidden with HideAxes.
+type ParallelCoordinates struct {
+	// Rows is a copy of the rows of the table.
+	Rows [][]float64
+
+	// Axes holds the vertical axis of each dimension.
+	// The range, ticks, label and scale of each axis may
+	// be set independently. The label is drawn above
+	// the axis.
+	Axes []*plot.Axis
+
+	// LineStyle is the style of the lines.
+	LineStyle draw.LineStyle
+
+	// Palette, if not nil, is used to color each line
+	// by its value in the ColorDimension column, scaled
+	// uniformly across the range of that dimension's axis.
+	Palette palette.Palette
+
+	// ColorDimension is the index of the column
+	// used to color the lines.
+	ColorDimension int
+}
+
+// NewParallelCoordinates returns a ParallelCoordinates plotter
+// of the table t, with the axes labelled with names if it is
+// not nil. The range of each axis is set to the range of its
+// dimension.
+func NewParallelCoordinates(t Tabler, names []string) (*ParallelCoordinates, error) {
+	r, c := t.Dims()
+	if r == 0 || c == 0 {
+		return nil, ErrNoData
+	}
+	if names != nil && len(names) != c {
+		return nil, errors.New("Number of names does not match the number of dimensions")
+	}
+	pc := &ParallelCoordinates{
+		Rows:      make([][]float64, r),
+		Axes:      make([]*plot.Axis, c),
+		LineStyle: DefaultLineStyle,
+	}
+	for i := range pc.Rows {
+		pc.Rows[i] = make([]float64, c)
+		for j := range pc.Rows[i] {
+			v := t.At(i, j)
+			if err := CheckFloats(v); err != nil {
+				return nil, err
+			}
+			pc.Rows[i][j] = v
+		}
+	}
+	for j := range pc.Axes {
+		a, err := plot.NewVerticalAxis()
+		if err != nil {
+			return nil, err
+		}
+		a.Label.YAlign = draw.YBottom
+		a.Min, a.Max = pc.Rows[0][j], pc.Rows[0][j]
+		for _, row := range pc.Rows {
+			if row[j] < a.Min {
+				a.Min = row[j]
+			}
+			if row[j] > a.Max {
+				a.Max = row[j]
+			}
+		}
+		if a.Min == a.Max {
+			a.Min--
+			a.Max++
+		}
+		if names != nil {
+			a.Label.Text = names[j]
+		}
+		pc.Axes[j] = a
+	}
+	return pc, nil
+}
+
+// Invert inverts the direction of the axis of dimension j.
+func (pc *ParallelCoordinates) Invert(j int) {
+	pc.Axes[j].Scale = plot.InvertedScale{Normalizer: pc.Axes[j].Scale}
+}
+
+// labelGap is the gap between the top of an
+// axis and its label.
+const labelGap = vg.Length(4)
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (pc *ParallelCoordinates) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	y0, y1 := trY(0), trY(1)
+
+	var pal []color.Color
+	if pc.Palette != nil {
+		pal = pc.Palette.Colors()
+		if len(pal) == 0 {
+			panic("parallel coordinates: empty palette")
+		}
+	}
+	for _, row := range pc.Rows {
+		line := make([]vg.Point, len(row))
+		for j, v := range row {
+			line[j] = vg.Point{
+				X: trX(float64(j)),
+				Y: y0 + vg.Length(pc.Axes[j].Norm(v))*(y1-y0),
+			}
+		}
+		sty := pc.LineStyle
+		if pal != nil {
+			a := pc.Axes[pc.ColorDimension]
+			sty.Color = paletteColor(pal, row[pc.ColorDimension], a.Min, a.Max, pal[0], pal[len(pal)-1])
+		}
+		c.StrokeLines(sty, c.ClipLinesXY(line)...)
+	}
+
+	ac := c
+	ac.Min.Y, ac.Max.Y = y0, y1
+	for j, axis := range pc.Axes {
+		a := *axis
+		label := a.Label.Text
+		a.Label.Text = ""
+		x := trX(float64(j))
+		a.DrawVertical(ac, x)
+		if label != "" {
+			sty := a.Label.TextStyle
+			sty.XAlign = draw.XCenter
+			c.FillText(sty, vg.Point{X: x, Y: y1 + labelGap}, label)
+		}
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface. The axes span
+// the Y range 0 to 1.
+func (pc *ParallelCoordinates) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return 0, float64(len(pc.Axes) - 1), 0, 1
+}
+
+// GlyphBoxes implements the GlyphBoxes method of the
+// plot.GlyphBoxer interface, reserving space for the
+// axis labels and for the ticks of the first axis.
+func (pc *ParallelCoordinates) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	var bs []plot.GlyphBox
+	for j, a := range pc.Axes {
+		x := plt.X.Norm(float64(j))
+		if a.Label.Text != "" {
+			w := a.Label.Width(a.Label.Text)
+			h := a.Label.Height(a.Label.Text)
+			bs = append(bs, plot.GlyphBox{
+				X: x,
+				Y: plt.Y.Norm(1),
+				Rectangle: vg.Rectangle{
+					Min: vg.Point{X: -w / 2},
+					Max: vg.Point{X: w / 2, Y: labelGap + h},
+				},
+			})
+		}
+		if j == 0 {
+			b := *a
+			b.Label.Text = ""
+			bs = append(bs, plot.GlyphBox{
+				X: x,
+				Y: plt.Y.Norm(0.5),
+				Rectangle: vg.Rectangle{
+					Min: vg.Point{X: -b.VerticalWidth()},
+				},
+			})
+		}
+	}
+	return bs
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (pc *ParallelCoordinates) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(pc.LineStyle, c.Min.X, y, c.Max.X, y)
+}
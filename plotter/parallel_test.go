@@ -0,0 +1,83 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+)
+
+// table is a Tabler of rows of values.
+type table [][]float64
+
+func (t table) Dims() (r, c int)    { return len(t), len(t[0]) }
+func (t table) At(i, j int) float64 { return t[i][j] }
+
+// ExampleParallelCoordinates draws a parallel coordinates
+// plot of four dimensions, with the lines colored by the
+// last dimension and the third axis inverted.
+func ExampleParallelCoordinates() {
+	rnd := rand.New(rand.NewSource(1))
+	t := make(table, 40)
+	for i := range t {
+		a := rnd.NormFloat64()
+		t[i] = []float64{
+			5 + a,
+			100 - 20*a + 10*rnd.NormFloat64(),
+			0.2 * rnd.Float64(),
+			a + 0.5*rnd.NormFloat64(),
+		}
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Parallel coordinates"
+	p.HideAxes()
+
+	pc, err := NewParallelCoordinates(t, []string{"Length", "Weight", "Ratio", "Score"})
+	if err != nil {
+		log.Panic(err)
+	}
+	pc.Invert(2)
+	pc.Palette = palette.Rainbow(12, palette.Blue, palette.Red, 1, 0.8, 1)
+	pc.ColorDimension = 3
+	p.Add(pc)
+
+	err = p.Save(300, 200, "testdata/parallelCoordinates.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestParallelCoordinates(t *testing.T) {
+	checkPlot(ExampleParallelCoordinates, t, "parallelCoordinates.png")
+}
+
+func TestParallelCoordinatesRange(t *testing.T) {
+	pc, err := NewParallelCoordinates(table{{1, 5}, {3, 5}, {2, 5}}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for j, want := range [][2]float64{{1, 3}, {4, 6}} {
+		if a := pc.Axes[j]; a.Min != want[0] || a.Max != want[1] {
+			t.Errorf("unexpected range of axis %d: got:[%v, %v] want:%v", j, a.Min, a.Max, want)
+		}
+	}
+	pc.Invert(0)
+	if got := pc.Axes[0].Norm(1); got != 1 {
+		t.Errorf("unexpected inverted normalization: got:%v want:1", got)
+	}
+
+	_, err = NewParallelCoordinates(table{{1, 2}}, []string{"a"})
+	if err == nil {
+		t.Error("expected error for mismatched names")
+	}
+}
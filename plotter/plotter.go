@@ -129,6 +129,22 @@ func XYRange(xys XYer) (xmin, xmax, ymin, ymax float64) {
 	return
 }
 
+// gapRange returns the minimum and maximum x and y
+// values of the points of xys that are not missing.
+func gapRange(xys XYer) (xmin, xmax, ymin, ymax float64) {
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	for i := 0; i < xys.Len(); i++ {
+		x, y := xys.XY(i)
+		if missing(x, y) {
+			continue
+		}
+		xmin, xmax = math.Min(xmin, x), math.Max(xmax, x)
+		ymin, ymax = math.Min(ymin, y), math.Max(ymax, y)
+	}
+	return
+}
+
 // XYs implements the XYer interface.
 type XYs []struct{ X, Y float64 }
 
@@ -146,6 +162,27 @@ func CopyXYs(data XYer) (XYs, error) {
 	return cpy, nil
 }
 
+// CopyXYsWithGaps returns an XYs that is a copy of the x and y
+// values from an XYer, or an error if one of the data points
+// contains an Infinity. Unlike CopyXYs, NaN values are accepted
+// and mark missing points.
+func CopyXYsWithGaps(data XYer) (XYs, error) {
+	cpy := make(XYs, data.Len())
+	for i := range cpy {
+		cpy[i].X, cpy[i].Y = data.XY(i)
+		if math.IsInf(cpy[i].X, 0) || math.IsInf(cpy[i].Y, 0) {
+			return nil, ErrInfinity
+		}
+	}
+	return cpy, nil
+}
+
+// missing returns whether a point is missing
+// because its x or y value is NaN.
+func missing(x, y float64) bool {
+	return math.IsNaN(x) || math.IsNaN(y)
+}
+
 func (xys XYs) Len() int {
 	return len(xys)
 }
@@ -254,3 +291,93 @@ type YErrors Errors
 func (ye YErrors) YError(i int) (float64, float64) {
 	return ye[i].Low, ye[i].High
 }
+
+// Len returns the number of errors.
+func (ye YErrors) Len() int {
+	return len(ye)
+}
+
+// OHLCer wraps the Len and OHLC methods.
+type OHLCer interface {
+	// Len returns the number of intervals.
+	Len() int
+
+	// OHLC returns the location of an interval along
+	// the X axis and its open, high, low and close values.
+	OHLC(int) (x, open, high, low, close float64)
+}
+
+// OHLCs implements the OHLCer interface using a slice.
+type OHLCs []struct{ X, Open, High, Low, Close float64 }
+
+// Len implements the Len method of the OHLCer interface.
+func (o OHLCs) Len() int {
+	return len(o)
+}
+
+// OHLC implements the OHLC method of the OHLCer interface.
+func (o OHLCs) OHLC(i int) (float64, float64, float64, float64, float64) {
+	return o[i].X, o[i].Open, o[i].High, o[i].Low, o[i].Close
+}
+
+// CopyOHLCs copies an OHLCer, returning an error if there
+// are no intervals or if any value is a NaN or Infinity.
+func CopyOHLCs(data OHLCer) (OHLCs, error) {
+	if data.Len() == 0 {
+		return nil, ErrNoData
+	}
+	cpy := make(OHLCs, data.Len())
+	for i := range cpy {
+		d := &cpy[i]
+		d.X, d.Open, d.High, d.Low, d.Close = data.OHLC(i)
+		if err := CheckFloats(d.X, d.Open, d.High, d.Low, d.Close); err != nil {
+			return nil, err
+		}
+	}
+	return cpy, nil
+}
+
+// XYUVer wraps the Len, XY and Vector methods.
+type XYUVer interface {
+	XYer
+
+	// Vector returns the X and Y components of the
+	// vector located at the ith point.
+	Vector(int) (u, v float64)
+}
+
+// XYUVs implements the XYUVer interface using a slice.
+type XYUVs []struct{ X, Y, U, V float64 }
+
+// Len implements the Len method of the XYUVer interface.
+func (xyuv XYUVs) Len() int {
+	return len(xyuv)
+}
+
+// XY implements the XY method of the XYUVer interface.
+func (xyuv XYUVs) XY(i int) (float64, float64) {
+	return xyuv[i].X, xyuv[i].Y
+}
+
+// Vector implements the Vector method of the XYUVer interface.
+func (xyuv XYUVs) Vector(i int) (float64, float64) {
+	return xyuv[i].U, xyuv[i].V
+}
+
+// CopyXYUVs copies an XYUVer, returning an error if there
+// are no points or if any value is a NaN or Infinity.
+func CopyXYUVs(data XYUVer) (XYUVs, error) {
+	if data.Len() == 0 {
+		return nil, ErrNoData
+	}
+	cpy := make(XYUVs, data.Len())
+	for i := range cpy {
+		d := &cpy[i]
+		d.X, d.Y = data.XY(i)
+		d.U, d.V = data.Vector(i)
+		if err := CheckFloats(d.X, d.Y, d.U, d.V); err != nil {
+			return nil, err
+		}
+	}
+	return cpy, nil
+}
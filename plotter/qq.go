@@ -0,0 +1,336 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// NormalQuantile is the quantile function of
+// the standard normal distribution.
+func NormalQuantile(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
+// ExponentialQuantile is the quantile function of
+// the exponential distribution with unit rate.
+func ExponentialQuantile(p float64) float64 {
+	return -math.Log1p(-p)
+}
+
+// UniformQuantile is the quantile function of the
+// uniform distribution on the unit interval.
+func UniformQuantile(p float64) float64 {
+	return p
+}
+
+// QQ implements the Plotter interface, drawing a quantile-quantile
+// plot of a sample against a theoretical distribution or against a
+// second sample, with a reference line and an optional confidence
+// envelope.
+type QQ struct {
+	// XYs holds the points of the plot. For a plot against
+	// a distribution, X is the theoretical quantile and Y
+	// is the sample quantile. For a probability plot, X is
+	// the sample value and Y is its plotting position.
+	XYs
+
+	// Reference holds the two end points of the
+	// reference line.
+	Reference XYs
+
+	// Lower and Upper hold the limits of the confidence
+	// envelope at each point. They are nil if there is
+	// no envelope.
+	Lower, Upper XYs
+
+	// GlyphStyle is the style of the glyphs
+	// drawn at each point.
+	draw.GlyphStyle
+
+	// LineStyle is the style of the reference line.
+	LineStyle draw.LineStyle
+
+	// EnvelopeColor is the color of the confidence envelope.
+	EnvelopeColor color.Color
+
+	// quantile is the theoretical quantile function, or
+	// nil if the plot is not against a distribution.
+	quantile func(p float64) float64
+
+	// slope and intercept describe the reference line
+	// of a plot against a distribution.
+	slope, intercept float64
+}
+
+// NewQQ returns a QQ plot of the values in vs against the
+// distribution with the given quantile function, such as
+// NormalQuantile. The ith smallest of the n values is plotted
+// against the theoretical quantile at (i-0.5)/n, and the
+// reference line passes through the first and third quartiles.
+func NewQQ(vs Valuer, quantile func(p float64) float64) (*QQ, error) {
+	ys, err := sortedValues(vs)
+	if err != nil {
+		return nil, err
+	}
+	q := newQQ()
+	q.quantile = quantile
+	n := len(ys)
+	q.XYs = make(XYs, n)
+	for i, y := range ys {
+		q.XYs[i].X = quantile(plottingPosition(i, n))
+		q.XYs[i].Y = y
+		if err := CheckFloats(q.XYs[i].X); err != nil {
+			return nil, err
+		}
+	}
+
+	x1, x3 := quantile(0.25), quantile(0.75)
+	y1, y3 := sampleQuantile(ys, 0.25), sampleQuantile(ys, 0.75)
+	if x3 != x1 {
+		q.slope = (y3 - y1) / (x3 - x1)
+	}
+	q.intercept = y1 - q.slope*x1
+	q.Reference = q.referenceLine()
+	return q, nil
+}
+
+// NewQQSamples returns a QQ plot of the quantiles of the values
+// in y against those of the values in x. If the samples differ
+// in size, the quantiles of the larger sample are interpolated
+// at the plotting positions of the smaller. The reference line
+// passes through the first and third quartiles.
+func NewQQSamples(x, y Valuer) (*QQ, error) {
+	xs, err := sortedValues(x)
+	if err != nil {
+		return nil, err
+	}
+	ys, err := sortedValues(y)
+	if err != nil {
+		return nil, err
+	}
+	n := len(xs)
+	if len(ys) < n {
+		n = len(ys)
+	}
+	if n < 2 {
+		return nil, errors.New("Too few values for QQ plot")
+	}
+	quantile := func(s []float64, i int) float64 {
+		if len(s) == n {
+			return s[i]
+		}
+		return sampleQuantile(s, plottingPosition(i, n))
+	}
+	q := newQQ()
+	q.XYs = make(XYs, n)
+	for i := range q.XYs {
+		q.XYs[i].X = quantile(xs, i)
+		q.XYs[i].Y = quantile(ys, i)
+	}
+
+	x1, x3 := sampleQuantile(xs, 0.25), sampleQuantile(xs, 0.75)
+	y1, y3 := sampleQuantile(ys, 0.25), sampleQuantile(ys, 0.75)
+	if x3 != x1 {
+		q.slope = (y3 - y1) / (x3 - x1)
+	}
+	q.intercept = y1 - q.slope*x1
+	q.Reference = q.referenceLine()
+	return q, nil
+}
+
+// NewProbabilityPlot returns a normal probability plot of the
+// values in vs, plotting each value against its plotting position.
+// The Y axis of the plot should be given a plot.ProbabilityScale
+// and plot.ProbabilityTicks, on which the reference line, the
+// cumulative distribution function of the normal distribution with
+// the sample mean and standard deviation, is straight.
+func NewProbabilityPlot(vs Valuer) (*QQ, error) {
+	xs, err := sortedValues(vs)
+	if err != nil {
+		return nil, err
+	}
+	n := len(xs)
+	if n < 2 {
+		return nil, errors.New("Too few values for probability plot")
+	}
+	q := newQQ()
+	q.XYs = make(XYs, n)
+	var mean float64
+	for i, x := range xs {
+		q.XYs[i].X = x
+		q.XYs[i].Y = plottingPosition(i, n)
+		mean += x
+	}
+	mean /= float64(n)
+	var ss float64
+	for _, x := range xs {
+		ss += sq(x - mean)
+	}
+	sd := math.Sqrt(ss / float64(n-1))
+	if sd == 0 {
+		return nil, errors.New("Zero variance in probability plot")
+	}
+
+	// The ends of the reference line are clamped to the range of
+	// the plotting positions, moving them along the line, so that
+	// the probabilities of distant values are not rounded to 0 or 1.
+	pmin, pmax := plottingPosition(0, n), plottingPosition(n-1, n)
+	end := func(x float64) struct{ X, Y float64 } {
+		p := 0.5 * math.Erfc(-(x-mean)/(sd*math.Sqrt2))
+		if p < pmin || p > pmax {
+			p = math.Max(pmin, math.Min(p, pmax))
+			x = mean + sd*NormalQuantile(p)
+		}
+		return struct{ X, Y float64 }{x, p}
+	}
+	q.Reference = XYs{end(xs[0]), end(xs[n-1])}
+	return q, nil
+}
+
+// newQQ returns a QQ with the default styles.
+func newQQ() *QQ {
+	return &QQ{
+		GlyphStyle:    DefaultGlyphStyle,
+		LineStyle:     DefaultLineStyle,
+		EnvelopeColor: color.Gray{Y: 220},
+	}
+}
+
+// sortedValues returns the values of vs sorted ascending.
+func sortedValues(vs Valuer) ([]float64, error) {
+	cpy, err := CopyValues(vs)
+	if err != nil {
+		return nil, err
+	}
+	sort.Float64s(cpy)
+	return cpy, nil
+}
+
+// plottingPosition returns the probability at which the
+// ith smallest of n values is plotted.
+func plottingPosition(i, n int) float64 {
+	return (float64(i) + 0.5) / float64(n)
+}
+
+// sampleQuantile returns the p quantile of the sorted values,
+// interpolated linearly between the order statistics at their
+// plotting positions.
+func sampleQuantile(sorted []float64, p float64) float64 {
+	h := float64(len(sorted))*p - 0.5
+	if h <= 0 {
+		return sorted[0]
+	}
+	i := int(h)
+	if i >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[i] + (h-float64(i))*(sorted[i+1]-sorted[i])
+}
+
+// referenceLine returns the end points of the reference
+// line across the X range of the points.
+func (q *QQ) referenceLine() XYs {
+	lo, hi := q.XYs[0].X, q.XYs[len(q.XYs)-1].X
+	return XYs{
+		{lo, q.intercept + q.slope*lo},
+		{hi, q.intercept + q.slope*hi},
+	}
+}
+
+// SetEnvelope sets the pointwise confidence envelope of a QQ plot
+// against a distribution at the given confidence level, such as
+// 0.95. The standard error of each sample quantile is estimated
+// from the slope of the reference line and the density of the
+// distribution at the theoretical quantile.
+func (q *QQ) SetEnvelope(confidence float64) error {
+	if q.quantile == nil {
+		return errors.New("Envelope requires a theoretical distribution")
+	}
+	if confidence <= 0 || confidence >= 1 {
+		return errors.New("Confidence not in (0, 1)")
+	}
+	z := NormalQuantile(1 - (1-confidence)/2)
+	n := len(q.XYs)
+	q.Lower = make(XYs, n)
+	q.Upper = make(XYs, n)
+	for i, pt := range q.XYs {
+		p := plottingPosition(i, n)
+
+		// The derivative of the quantile function is
+		// the reciprocal of the density.
+		h := 1e-6 * math.Min(p, 1-p)
+		dq := (q.quantile(p+h) - q.quantile(p-h)) / (2 * h)
+		se := math.Abs(q.slope) * dq * math.Sqrt(p*(1-p)/float64(n))
+		y := q.intercept + q.slope*pt.X
+		q.Lower[i].X, q.Lower[i].Y = pt.X, y-z*se
+		q.Upper[i].X, q.Upper[i].Y = pt.X, y+z*se
+	}
+	return nil
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (q *QQ) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+
+	if q.Lower != nil && q.EnvelopeColor != nil {
+		poly := make([]vg.Point, 0, 2*len(q.Lower))
+		for _, p := range q.Upper {
+			poly = append(poly, vg.Point{X: trX(p.X), Y: trY(p.Y)})
+		}
+		for i := len(q.Lower) - 1; i >= 0; i-- {
+			poly = append(poly, vg.Point{X: trX(q.Lower[i].X), Y: trY(q.Lower[i].Y)})
+		}
+		c.FillPolygon(q.EnvelopeColor, c.ClipPolygonXY(poly))
+	}
+
+	line := make([]vg.Point, len(q.Reference))
+	for i, p := range q.Reference {
+		line[i] = vg.Point{X: trX(p.X), Y: trY(p.Y)}
+	}
+	c.StrokeLines(q.LineStyle, c.ClipLinesXY(line)...)
+
+	for _, p := range q.XYs {
+		c.DrawGlyph(q.GlyphStyle, vg.Point{X: trX(p.X), Y: trY(p.Y)})
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (q *QQ) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax, ymin, ymax = XYRange(q.XYs)
+	for _, env := range []XYs{q.Lower, q.Upper} {
+		if env == nil {
+			continue
+		}
+		_, _, lo, hi := XYRange(env)
+		ymin, ymax = math.Min(ymin, lo), math.Max(ymax, hi)
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface.
+func (q *QQ) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, len(q.XYs))
+	for i, p := range q.XYs {
+		bs[i].X = plt.X.Norm(p.X)
+		bs[i].Y = plt.Y.Norm(p.Y)
+		bs[i].Rectangle = q.GlyphStyle.Rectangle()
+	}
+	return bs
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (q *QQ) Thumbnail(c *draw.Canvas) {
+	c.DrawGlyph(q.GlyphStyle, c.Center())
+}
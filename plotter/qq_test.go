@@ -0,0 +1,161 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/vgimg"
+)
+
+// ExampleQQ draws a normal QQ plot of a skewed
+// sample with a 95% confidence envelope.
+func ExampleQQ() {
+	rnd := rand.New(rand.NewSource(1))
+	vs := make(Values, 60)
+	for i := range vs {
+		vs[i] = rnd.ExpFloat64()
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Normal QQ plot"
+	p.X.Label.Text = "Theoretical quantiles"
+	p.Y.Label.Text = "Sample quantiles"
+
+	q, err := NewQQ(vs, NormalQuantile)
+	if err != nil {
+		log.Panic(err)
+	}
+	err = q.SetEnvelope(0.95)
+	if err != nil {
+		log.Panic(err)
+	}
+	q.Radius = vg.Points(2)
+	p.Add(q)
+
+	err = p.Save(200, 200, "testdata/qq.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestQQ(t *testing.T) {
+	checkPlot(ExampleQQ, t, "qq.png")
+}
+
+// ExampleQQ_probability draws a normal probability plot
+// on a probability-scaled axis.
+func ExampleQQ_probability() {
+	rnd := rand.New(rand.NewSource(1))
+	vs := make(Values, 40)
+	for i := range vs {
+		vs[i] = 10 + 2*rnd.NormFloat64()
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Normal probability plot"
+	p.X.Label.Text = "Value"
+	p.Y.Label.Text = "Probability"
+	p.Y.Scale = plot.ProbabilityScale{}
+	p.Y.Tick.Marker = plot.ProbabilityTicks{}
+
+	q, err := NewProbabilityPlot(vs)
+	if err != nil {
+		log.Panic(err)
+	}
+	q.Radius = vg.Points(2)
+	p.Add(q)
+
+	err = p.Save(200, 200, "testdata/probabilityPlot.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestProbabilityPlot(t *testing.T) {
+	checkPlot(ExampleQQ_probability, t, "probabilityPlot.png")
+}
+
+func TestQQReference(t *testing.T) {
+	// A sample at the exact quantiles of a shifted and scaled
+	// distribution lies on the reference line, up to the linear
+	// interpolation of the sample quartiles.
+	for _, quantile := range []func(float64) float64{NormalQuantile, ExponentialQuantile, UniformQuantile} {
+		n := 21
+		vs := make(Values, n)
+		for i := range vs {
+			vs[i] = 3 + 2*quantile(plottingPosition(i, n))
+		}
+		q, err := NewQQ(vs, quantile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if math.Abs(q.slope-2) > 1e-2 || math.Abs(q.intercept-3) > 1e-2 {
+			t.Errorf("unexpected reference line: got slope %v intercept %v want 2 and 3", q.slope, q.intercept)
+		}
+		if err := q.SetEnvelope(0.95); err != nil {
+			t.Errorf("unexpected envelope error: %v", err)
+		}
+		for i, pt := range q.XYs {
+			if pt.Y < q.Lower[i].Y || pt.Y > q.Upper[i].Y {
+				t.Errorf("point %d outside envelope: %v not in [%v, %v]", i, pt.Y, q.Lower[i].Y, q.Upper[i].Y)
+			}
+		}
+	}
+}
+
+func TestQQSamples(t *testing.T) {
+	q, err := NewQQSamples(Values{4, 0, 2}, Values{0, 10, 5, 20, 15})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := XYs{{0, 5.0 / 3}, {2, 10}, {4, 55.0 / 3}}
+	for i, p := range q.XYs {
+		if p.X != want[i].X || math.Abs(p.Y-want[i].Y) > 1e-12 {
+			t.Errorf("unexpected point %d: got:%v want:%v", i, p, want[i])
+		}
+	}
+	if err := q.SetEnvelope(0.95); err == nil {
+		t.Error("expected error for envelope of two-sample plot")
+	}
+}
+
+func TestProbabilityPlotOutlier(t *testing.T) {
+	// The outlier is far enough from the mean that its
+	// probability would be rounded to 1.
+	vs := make(Values, 100)
+	vs[99] = 1000
+	q, err := NewProbabilityPlot(vs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, pt := range q.Reference {
+		if pt.Y <= 0 || pt.Y >= 1 {
+			t.Errorf("reference probability out of range: %v", pt)
+		}
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(q)
+	p.Y.Scale = plot.ProbabilityScale{}
+	p.Y.Tick.Marker = plot.ProbabilityTicks{}
+	c := draw.New(vgimg.New(200, 200))
+	p.Draw(c)
+}
@@ -0,0 +1,256 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// GridXYUV describes a vector field on a grid.
+type GridXYUV interface {
+	// Dims returns the dimensions of the grid.
+	Dims() (c, r int)
+
+	// Vector returns the X and Y components of the
+	// vector at (c, r). It will panic if c or r are
+	// out of bounds for the grid.
+	Vector(c, r int) (u, v float64)
+
+	// X returns the coordinate for the column at the index c.
+	// It will panic if c is out of bounds for the grid.
+	X(c int) float64
+
+	// Y returns the coordinate for the row at the index r.
+	// It will panic if r is out of bounds for the grid.
+	Y(r int) float64
+}
+
+// Quiver implements the Plotter interface, drawing a
+// vector field as arrows located at a set of points.
+// The length of each arrow is proportional to the
+// magnitude of its vector.
+type Quiver struct {
+	// XYUVs is a copy of the vectors being plotted.
+	XYUVs
+
+	// Scale is the length of an arrow in data units
+	// per unit of vector magnitude.
+	Scale float64
+
+	// LineStyle is the style of the arrow shafts. The
+	// color of the style is used for the arrows when
+	// Palette is nil.
+	LineStyle draw.LineStyle
+
+	// HeadSize is the length of the arrow heads. Heads
+	// are shortened to at most half of the length of
+	// short arrows.
+	HeadSize vg.Length
+
+	// Palette is an optional color palette used to
+	// color the arrows according to their magnitude.
+	Palette palette.Palette
+
+	// Min and Max define the range of magnitudes
+	// spanned by the Palette. Magnitudes outside
+	// the range are given the end colors.
+	Min, Max float64
+}
+
+// NewQuiver returns a new Quiver plotter drawing the vectors
+// in data. Scale is set so that the longest arrow is a little
+// shorter than the mean distance between points, and Min and
+// Max are set to the range of the magnitudes of the vectors.
+func NewQuiver(data XYUVer) (*Quiver, error) {
+	cpy, err := CopyXYUVs(data)
+	if err != nil {
+		return nil, err
+	}
+	q := &Quiver{
+		XYUVs:     cpy,
+		Scale:     1,
+		LineStyle: DefaultLineStyle,
+		HeadSize:  vg.Points(4),
+		Min:       math.Inf(1),
+		Max:       math.Inf(-1),
+	}
+	for _, d := range cpy {
+		m := math.Hypot(d.U, d.V)
+		q.Min = math.Min(q.Min, m)
+		q.Max = math.Max(q.Max, m)
+	}
+
+	xmin, xmax, ymin, ymax := XYRange(cpy)
+	xmin, xmax = widenRange(xmin, xmax)
+	ymin, ymax = widenRange(ymin, ymax)
+	if q.Max > 0 {
+		spacing := math.Sqrt((xmax - xmin) * (ymax - ymin) / float64(len(cpy)))
+		q.Scale = 0.9 * spacing / q.Max
+	}
+	return q, nil
+}
+
+// NewQuiverGrid returns a new Quiver plotter drawing the
+// vectors of the grid g at each of the grid points, as
+// for NewQuiver.
+func NewQuiverGrid(g GridXYUV) (*Quiver, error) {
+	c, r := g.Dims()
+	data := make(XYUVs, 0, c*r)
+	for j := 0; j < r; j++ {
+		for i := 0; i < c; i++ {
+			u, v := g.Vector(i, j)
+			data = append(data, struct{ X, Y, U, V float64 }{g.X(i), g.Y(j), u, v})
+		}
+	}
+	return NewQuiver(data)
+}
+
+// colorOf returns the color of an arrow with magnitude m.
+func (q *Quiver) colorOf(pal []color.Color, m float64) color.Color {
+	if len(pal) == 0 {
+		return q.LineStyle.Color
+	}
+	return paletteColor(pal, m, q.Min, q.Max, pal[0], pal[len(pal)-1])
+}
+
+// Plot implements the plot.Plotter interface.
+func (q *Quiver) Plot(c draw.Canvas, plt *plot.Plot) {
+	var pal []color.Color
+	if q.Palette != nil {
+		pal = q.Palette.Colors()
+		if len(pal) == 0 {
+			panic("quiver: empty palette")
+		}
+	}
+
+	trX, trY := plt.Transforms(&c)
+	for _, d := range q.XYUVs {
+		m := math.Hypot(d.U, d.V)
+		if m == 0 {
+			continue
+		}
+		tail := vg.Point{X: trX(d.X), Y: trY(d.Y)}
+		tip := vg.Point{X: trX(d.X + d.U*q.Scale), Y: trY(d.Y + d.V*q.Scale)}
+
+		sty := q.LineStyle
+		sty.Color = q.colorOf(pal, m)
+		drawArrow(&c, sty, tail, tip, q.HeadSize)
+	}
+}
+
+// drawArrow draws an arrow from tail to tip, shortening the
+// head to at most half the length of the arrow.
+func drawArrow(c *draw.Canvas, sty draw.LineStyle, tail, tip vg.Point, head vg.Length) {
+	d := tip.Sub(tail)
+	if l := vg.Length(math.Hypot(float64(d.X), float64(d.Y))) / 2; head > l {
+		head = l
+	}
+	c.StrokeLines(sty, c.ClipLinesXY([]vg.Point{tail, tip})...)
+	fillArrowHead(c, sty.Color, tail, tip, head)
+}
+
+// DataRange implements the plot.DataRanger interface,
+// including the tips of the arrows in the range.
+func (q *Quiver) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, ymin = math.Inf(1), math.Inf(1)
+	xmax, ymax = math.Inf(-1), math.Inf(-1)
+	for _, d := range q.XYUVs {
+		tx, ty := d.X+d.U*q.Scale, d.Y+d.V*q.Scale
+		xmin = math.Min(xmin, math.Min(d.X, tx))
+		xmax = math.Max(xmax, math.Max(d.X, tx))
+		ymin = math.Min(ymin, math.Min(d.Y, ty))
+		ymax = math.Max(ymax, math.Max(d.Y, ty))
+	}
+	return xmin, xmax, ymin, ymax
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (q *Quiver) Thumbnail(c *draw.Canvas) {
+	sty := q.LineStyle
+	if q.Palette != nil {
+		if pal := q.Palette.Colors(); len(pal) != 0 {
+			sty.Color = pal[len(pal)-1]
+		}
+	}
+	y := c.Center().Y
+	drawArrow(c, sty, vg.Point{X: c.Min.X, Y: y}, vg.Point{X: c.Max.X, Y: y}, q.HeadSize)
+}
+
+// QuiverKey implements the Plotter interface, drawing a
+// labelled reference arrow showing the scale of the arrows
+// of a Quiver. The arrow is drawn with the LineStyle of the
+// Quiver irrespective of its Palette.
+type QuiverKey struct {
+	// Quiver is the plotter whose scale is shown.
+	Quiver *Quiver
+
+	// Magnitude is the magnitude of the reference arrow.
+	Magnitude float64
+
+	// Label is the text drawn above the reference arrow.
+	Label string
+
+	// X and Y are the location of the center of the
+	// reference arrow as fractions of the width and
+	// height of the plotting area.
+	X, Y float64
+
+	// TextStyle is the style of the label.
+	TextStyle draw.TextStyle
+}
+
+// Key returns a QuiverKey drawing a horizontal reference
+// arrow of the given magnitude near the top right corner
+// of the plotting area. The label is drawn using the
+// DefaultFont and DefaultFontSize.
+func (q *Quiver) Key(magnitude float64, label string) (*QuiverKey, error) {
+	if magnitude <= 0 {
+		return nil, errors.New("Key magnitude was not positive")
+	}
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	return &QuiverKey{
+		Quiver:    q,
+		Magnitude: magnitude,
+		Label:     label,
+		X:         0.85,
+		Y:         0.92,
+		TextStyle: draw.TextStyle{
+			Font:   fnt,
+			XAlign: draw.XCenter,
+			YAlign: draw.YBottom,
+		},
+	}, nil
+}
+
+// Plot implements the plot.Plotter interface.
+func (k *QuiverKey) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, _ := plt.Transforms(&c)
+	q := k.Quiver
+	length := trX(plt.X.Min+k.Magnitude*q.Scale) - trX(plt.X.Min)
+
+	center := vg.Point{
+		X: c.Min.X + vg.Length(k.X)*c.Size().X,
+		Y: c.Min.Y + vg.Length(k.Y)*c.Size().Y,
+	}
+	tail := vg.Point{X: center.X - length/2, Y: center.Y}
+	tip := vg.Point{X: center.X + length/2, Y: center.Y}
+
+	drawArrow(&c, q.LineStyle, tail, tip, q.HeadSize)
+
+	if k.Label != "" {
+		center.Y += q.HeadSize / 2
+		c.FillText(k.TextStyle, center, k.Label)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+)
+
+// vortex is a GridXYUV holding a vortex centered
+// at the origin in a uniform flow to the right.
+type vortex struct {
+	n        int
+	min, max float64
+}
+
+func (g vortex) Dims() (c, r int) { return g.n, g.n }
+func (g vortex) X(c int) float64  { return g.min + float64(c)*(g.max-g.min)/float64(g.n-1) }
+func (g vortex) Y(r int) float64  { return g.min + float64(r)*(g.max-g.min)/float64(g.n-1) }
+func (g vortex) Vector(c, r int) (u, v float64) {
+	x, y := g.X(c), g.Y(r)
+	d := x*x + y*y + 0.5
+	return 0.5 - y/d, x / d
+}
+
+// ExampleQuiver draws the vector field of a vortex with
+// the arrows colored by magnitude and a reference arrow.
+func ExampleQuiver() {
+	q, err := NewQuiverGrid(vortex{n: 13, min: -3, max: 3})
+	if err != nil {
+		log.Panic(err)
+	}
+	q.Palette = palette.Rainbow(12, palette.Blue, palette.Red, 1, 1, 1)
+
+	key, err := q.Key(1, "1 m/s")
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Quiver"
+	p.Add(q, key)
+
+	err = p.Save(250, 250, "testdata/quiver.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestQuiver(t *testing.T) {
+	checkPlot(ExampleQuiver, t, "quiver.png")
+}
+
+func TestQuiverScale(t *testing.T) {
+	data := XYUVs{
+		{X: 0, Y: 0, U: 3, V: 4},
+		{X: 1, Y: 0, U: 0, V: 0},
+		{X: 0, Y: 1, U: 1, V: 0},
+		{X: 1, Y: 1, U: 0, V: -1},
+	}
+	q, err := NewQuiver(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.Min != 0 || q.Max != 5 {
+		t.Errorf("unexpected magnitude range: got:[%v,%v] want:[0,5]", q.Min, q.Max)
+	}
+	// The mean spacing of the points is 0.5.
+	if want := 0.9 * 0.5 / 5; math.Abs(q.Scale-want) > 1e-12 {
+		t.Errorf("unexpected scale: got:%v want:%v", q.Scale, want)
+	}
+
+	_, err = NewQuiver(XYUVs{{X: 0, Y: 0, U: math.NaN(), V: 0}})
+	if err != ErrNaN {
+		t.Errorf("unexpected error for NaN vector: got:%v want:%v", err, ErrNaN)
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// RadarSeries is a series of values drawn by a Radar,
+// one value per metric.
+type RadarSeries struct {
+	// Values holds the value of each metric.
+	Values
+
+	// LineStyle is the style of the outline
+	// of the series polygon.
+	LineStyle draw.LineStyle
+
+	// Color is the fill color of the series polygon.
+	// If it is nil, the polygon is not filled. A
+	// translucent color allows the series beneath
+	// to show through.
+	Color color.Color
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (s *RadarSeries) Thumbnail(c *draw.Canvas) {
+	if s.Color != nil {
+		fillThumbnail(c, s.Color)
+	}
+	y := c.Center().Y
+	c.StrokeLine2(s.LineStyle, c.Min.X, y, c.Max.X, y)
+}
+
+// Radar implements the Plotter interface, drawing a radar
+// chart of series of values. Each metric has a radial axis,
+// starting at the top and continuing clockwise, and each
+// series is drawn as a polygon joining its values on the
+// axes. The chart is drawn as a circle of unit radius
+// centered on the origin, scaled to fit the canvas
+// whatever its aspect, and the axes of the plot are hidden
+// when the Radar is added to it.
+type Radar struct {
+	// Metrics holds the names of the metrics,
+	// which are drawn around the perimeter.
+	Metrics []string
+
+	// Series holds the series of the chart,
+	// drawn in order.
+	Series []*RadarSeries
+
+	// Min and Max hold the values of each metric
+	// at the center and at the perimeter.
+	Min, Max []float64
+
+	// Rings is the number of grid rings.
+	Rings int
+
+	// GridStyle is the style of the grid rings
+	// and of the radial axes.
+	GridStyle draw.LineStyle
+
+	// TextStyle is the style of the metric names.
+	TextStyle draw.TextStyle
+
+	// Padding is the distance between the
+	// perimeter and the metric names.
+	Padding vg.Length
+}
+
+// NewRadar returns a Radar of the series of values for the
+// named metrics. There must be at least three metrics and
+// each series must have a value for each metric. The range
+// of each metric extends from the lesser of zero and its
+// minimum value to its maximum value.
+func NewRadar(metrics []string, series ...Valuer) (*Radar, error) {
+	if len(metrics) < 3 {
+		return nil, errors.New("Radar requires at least three metrics")
+	}
+	if len(series) == 0 {
+		return nil, ErrNoData
+	}
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	r := &Radar{
+		Metrics: metrics,
+		Series:  make([]*RadarSeries, len(series)),
+		Min:     make([]float64, len(metrics)),
+		Max:     make([]float64, len(metrics)),
+		Rings:   4,
+		GridStyle: draw.LineStyle{
+			Color: color.Gray{Y: 192},
+			Width: vg.Points(0.5),
+		},
+		TextStyle: draw.TextStyle{Font: fnt},
+		Padding:   vg.Points(4),
+	}
+	for i, vs := range series {
+		if vs.Len() != len(metrics) {
+			return nil, errors.New("Number of values does not match the number of metrics")
+		}
+		cpy, err := CopyValues(vs)
+		if err != nil {
+			return nil, err
+		}
+		r.Series[i] = &RadarSeries{
+			Values:    cpy,
+			LineStyle: DefaultLineStyle,
+			Color:     color.NRGBA{R: 128, G: 128, B: 128, A: 64},
+		}
+	}
+	for j := range metrics {
+		r.Max[j] = math.Inf(-1)
+		for _, s := range r.Series {
+			r.Min[j] = math.Min(r.Min[j], s.Values[j])
+			r.Max[j] = math.Max(r.Max[j], s.Values[j])
+		}
+		if r.Max[j] == r.Min[j] {
+			r.Max[j]++
+		}
+	}
+	return r, nil
+}
+
+// HidesAxes implements the plot.AxesHider interface.
+func (r *Radar) HidesAxes() bool { return true }
+
+// direction returns the unit vector of the
+// radial axis of metric j.
+func (r *Radar) direction(j int) (x, y float64) {
+	a := math.Pi/2 - 2*math.Pi*float64(j)/float64(len(r.Metrics))
+	return math.Cos(a), math.Sin(a)
+}
+
+// labelStyle returns the style of the name of the
+// metric j, aligned away from the perimeter.
+func (r *Radar) labelStyle(j int) draw.TextStyle {
+	const eps = 0.1
+	sty := r.TextStyle
+	x, y := r.direction(j)
+	switch {
+	case x > eps:
+		sty.XAlign = draw.XLeft
+	case x < -eps:
+		sty.XAlign = draw.XRight
+	default:
+		sty.XAlign = draw.XCenter
+	}
+	switch {
+	case y > eps:
+		sty.YAlign = draw.YBottom
+	case y < -eps:
+		sty.YAlign = draw.YTop
+	default:
+		sty.YAlign = draw.YCenter
+	}
+	return sty
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (r *Radar) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	center := vg.Point{X: trX(0), Y: trY(0)}
+	rad := trX(1) - center.X
+	if h := trY(1) - center.Y; h < rad {
+		rad = h
+	}
+	point := func(j int, f float64) vg.Point {
+		x, y := r.direction(j)
+		return vg.Point{
+			X: center.X + vg.Length(f*x)*rad,
+			Y: center.Y + vg.Length(f*y)*rad,
+		}
+	}
+	polygon := func(f func(j int) float64) []vg.Point {
+		pts := make([]vg.Point, len(r.Metrics)+1)
+		for j := range r.Metrics {
+			pts[j] = point(j, f(j))
+		}
+		pts[len(r.Metrics)] = pts[0]
+		return pts
+	}
+
+	for k := 1; k <= r.Rings; k++ {
+		f := float64(k) / float64(r.Rings)
+		c.StrokeLines(r.GridStyle, polygon(func(int) float64 { return f }))
+	}
+	for j := range r.Metrics {
+		c.StrokeLines(r.GridStyle, []vg.Point{center, point(j, 1)})
+	}
+
+	for _, s := range r.Series {
+		pts := polygon(func(j int) float64 {
+			return (s.Values[j] - r.Min[j]) / (r.Max[j] - r.Min[j])
+		})
+		if s.Color != nil {
+			c.FillPolygon(s.Color, c.ClipPolygonXY(pts))
+		}
+		c.StrokeLines(s.LineStyle, c.ClipLinesXY(pts)...)
+	}
+
+	for j, m := range r.Metrics {
+		x, y := r.direction(j)
+		pt := point(j, 1)
+		pt.X += vg.Length(x) * r.Padding
+		pt.Y += vg.Length(y) * r.Padding
+		c.FillText(r.labelStyle(j), pt, m)
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (r *Radar) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return -1, 1, -1, 1
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface, reserving
+// space for the metric names.
+func (r *Radar) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, len(r.Metrics))
+	for j, m := range r.Metrics {
+		x, y := r.direction(j)
+		rect := r.labelStyle(j).Rectangle(m)
+		off := vg.Point{X: vg.Length(x) * r.Padding, Y: vg.Length(y) * r.Padding}
+		bs[j] = plot.GlyphBox{
+			X: plt.X.Norm(x),
+			Y: plt.Y.Norm(y),
+			Rectangle: vg.Rectangle{
+				Min: rect.Min.Add(off),
+				Max: rect.Max.Add(off),
+			},
+		}
+	}
+	return bs
+}
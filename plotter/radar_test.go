@@ -0,0 +1,94 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// ExampleRadar draws a radar chart comparing
+// three items across six metrics.
+func ExampleRadar() {
+	metrics := []string{"Speed", "Range", "Comfort", "Safety", "Price", "Efficiency"}
+	r, err := NewRadar(metrics,
+		Values{8, 5, 6, 7, 4, 6},
+		Values{5, 9, 7, 8, 6, 8},
+		Values{6, 4, 9, 6, 8, 5},
+	)
+	if err != nil {
+		log.Panic(err)
+	}
+	for j := range r.Max {
+		r.Min[j], r.Max[j] = 0, 10
+	}
+	r.Rings = 5
+	for i, s := range r.Series {
+		c := []color.NRGBA{
+			{R: 204, G: 51, B: 51, A: 255},
+			{R: 51, G: 102, B: 204, A: 255},
+			{R: 51, G: 153, B: 51, A: 255},
+		}[i]
+		s.LineStyle.Color = c
+		s.LineStyle.Width = vg.Points(1.5)
+		c.A = 48
+		s.Color = c
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Radar chart"
+	p.Add(r)
+	p.Legend.Add("Roadster", r.Series[0])
+	p.Legend.Add("Tourer", r.Series[1])
+	p.Legend.Add("Saloon", r.Series[2])
+
+	err = p.Save(300, 220, "testdata/radar.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestRadar(t *testing.T) {
+	checkPlot(ExampleRadar, t, "radar.png")
+}
+
+func TestNewRadar(t *testing.T) {
+	r, err := NewRadar([]string{"a", "b", "c"}, Values{1, -2, 3}, Values{2, 4, 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantMin, wantMax := []float64{0, -2, 0}, []float64{2, 4, 3}
+	for j := range r.Metrics {
+		if r.Min[j] != wantMin[j] || r.Max[j] != wantMax[j] {
+			t.Errorf("unexpected range of metric %d: got:[%v, %v] want:[%v, %v]",
+				j, r.Min[j], r.Max[j], wantMin[j], wantMax[j])
+		}
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(r)
+	if p.X.Width != 0 || p.Y.Width != 0 {
+		t.Error("expected axes to be hidden")
+	}
+
+	_, err = NewRadar([]string{"a", "b"}, Values{1, 2})
+	if err == nil {
+		t.Error("expected error for too few metrics")
+	}
+	_, err = NewRadar([]string{"a", "b", "c"}, Values{1, 2})
+	if err == nil {
+		t.Error("expected error for mismatched values")
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Ridgeline implements the Plotter interface, drawing a ridgeline
+// plot of distributions as filled curves stacked vertically. The
+// baseline of ridge i is at the Y value i, so the ridges can be
+// labelled with plot.NominalY, and the curves are scaled so that
+// the tallest reaches Overlap ridges above its baseline.
+//
+// The ridges are drawn from the top down, so that each ridge
+// is drawn in front of those it overlaps.
+type Ridgeline struct {
+	// Curves holds the curve of each ridge,
+	// with non-negative heights.
+	Curves []XYs
+
+	// Overlap is the height of the tallest curve
+	// in units of the spacing of the baselines.
+	Overlap float64
+
+	// FillColor is the color used to fill the ridges.
+	// If it is nil, the ridges are not filled.
+	FillColor color.Color
+
+	// FillAlpha is the opacity of the fill,
+	// scaling the alpha of FillColor.
+	FillAlpha float64
+
+	// LineStyle is the style of the curves.
+	draw.LineStyle
+}
+
+// NewRidgeline returns a Ridgeline of the kernel density
+// estimates of the values in each of vs, sampled at n points.
+// The densities are estimated with a Gaussian kernel using
+// Silverman's rule of thumb for the bandwidth.
+func NewRidgeline(vs []Valuer, n int) (*Ridgeline, error) {
+	if n < 2 {
+		return nil, errors.New("Too few density samples")
+	}
+	if len(vs) == 0 {
+		return nil, ErrNoData
+	}
+	r := newRidgeline(len(vs))
+	for i, v := range vs {
+		s, err := sortedValues(v)
+		if err != nil {
+			return nil, err
+		}
+		if len(s) == 0 {
+			return nil, ErrNoData
+		}
+		r.Curves[i] = density(s, n)
+	}
+	return r, nil
+}
+
+// NewRidgelineHistograms returns a Ridgeline of the histograms,
+// drawing each as the outline of its bars. Histograms that are
+// to be compared should have been normalized.
+func NewRidgelineHistograms(hs []*Histogram) (*Ridgeline, error) {
+	if len(hs) == 0 {
+		return nil, ErrNoData
+	}
+	r := newRidgeline(len(hs))
+	for i, h := range hs {
+		if len(h.Bins) == 0 {
+			return nil, ErrNoData
+		}
+		c := XYs{{h.Bins[0].Min, 0}}
+		for _, b := range h.Bins {
+			if b.Weight < 0 {
+				return nil, errors.New("Negative histogram weight")
+			}
+			c = append(c, struct{ X, Y float64 }{b.Min, b.Weight}, struct{ X, Y float64 }{b.Max, b.Weight})
+		}
+		c = append(c, struct{ X, Y float64 }{h.Bins[len(h.Bins)-1].Max, 0})
+		r.Curves[i] = c
+	}
+	return r, nil
+}
+
+// newRidgeline returns a Ridgeline of k
+// ridges with the default styles.
+func newRidgeline(k int) *Ridgeline {
+	return &Ridgeline{
+		Curves:    make([]XYs, k),
+		Overlap:   1.5,
+		FillColor: color.Gray{Y: 192},
+		FillAlpha: 0.8,
+		LineStyle: DefaultLineStyle,
+	}
+}
+
+// density returns the Gaussian kernel density estimate of the
+// sorted values, sampled at n points spanning three bandwidths
+// beyond the extreme values.
+func density(sorted []float64, n int) XYs {
+	m := len(sorted)
+	var mean float64
+	for _, v := range sorted {
+		mean += v
+	}
+	mean /= float64(m)
+	var ss float64
+	for _, v := range sorted {
+		ss += sq(v - mean)
+	}
+	spread := math.Sqrt(ss / float64(m))
+	if iqr := (sampleQuantile(sorted, 0.75) - sampleQuantile(sorted, 0.25)) / 1.34; iqr > 0 && iqr < spread {
+		spread = iqr
+	}
+	h := 0.9 * spread * math.Pow(float64(m), -0.2)
+	if h == 0 {
+		h = 1
+	}
+
+	lo, hi := sorted[0]-3*h, sorted[m-1]+3*h
+	norm := 1 / (float64(m) * h * math.Sqrt(2*math.Pi))
+	xys := make(XYs, n)
+	for i := range xys {
+		x := lo + (hi-lo)*float64(i)/float64(n-1)
+		var sum float64
+		for _, v := range sorted {
+			sum += math.Exp(-sq((x-v)/h) / 2)
+		}
+		xys[i].X, xys[i].Y = x, sum*norm
+	}
+	return xys
+}
+
+// scale returns the factor by which the
+// heights of the curves are multiplied.
+func (r *Ridgeline) scale() float64 {
+	var max float64
+	for _, c := range r.Curves {
+		for _, p := range c {
+			max = math.Max(max, p.Y)
+		}
+	}
+	if max == 0 {
+		return 0
+	}
+	return r.Overlap / max
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (r *Ridgeline) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	s := r.scale()
+
+	var fill color.Color
+	if r.FillColor != nil {
+		nc := color.NRGBAModel.Convert(r.FillColor).(color.NRGBA)
+		nc.A = uint8(float64(nc.A) * r.FillAlpha)
+		fill = nc
+	}
+	for i := len(r.Curves) - 1; i >= 0; i-- {
+		base := float64(i)
+		line := make([]vg.Point, len(r.Curves[i]))
+		for j, p := range r.Curves[i] {
+			line[j] = vg.Point{X: trX(p.X), Y: trY(base + p.Y*s)}
+		}
+		if fill != nil {
+			poly := append([]vg.Point{{X: line[0].X, Y: trY(base)}}, line...)
+			poly = append(poly, vg.Point{X: line[len(line)-1].X, Y: trY(base)})
+			c.FillPolygon(fill, c.ClipPolygonXY(poly))
+		}
+		c.StrokeLines(r.LineStyle, c.ClipLinesXY(line)...)
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (r *Ridgeline) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	s := r.scale()
+	for i, c := range r.Curves {
+		cxmin, cxmax, _, cymax := XYRange(c)
+		xmin, xmax = math.Min(xmin, cxmin), math.Max(xmax, cxmax)
+		ymax = math.Max(ymax, float64(i)+cymax*s)
+	}
+	return xmin, xmax, 0, ymax
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (r *Ridgeline) Thumbnail(c *draw.Canvas) {
+	if r.FillColor != nil {
+		fillThumbnail(c, r.FillColor)
+	}
+	y := c.Center().Y
+	c.StrokeLine2(r.LineStyle, c.Min.X, y, c.Max.X, y)
+}
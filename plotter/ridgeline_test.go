@@ -0,0 +1,92 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/plot"
+)
+
+// ExampleRidgeline draws a ridgeline plot of the
+// distributions of daily values in six months.
+func ExampleRidgeline() {
+	rnd := rand.New(rand.NewSource(1))
+	months := []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun"}
+	vs := make([]Valuer, len(months))
+	for i := range vs {
+		v := make(Values, 30)
+		for j := range v {
+			v[j] = 5 + 3*float64(i) + (2+0.5*float64(i))*rnd.NormFloat64()
+			if j%3 == 0 {
+				v[j] += 6
+			}
+		}
+		vs[i] = v
+	}
+
+	r, err := NewRidgeline(vs, 100)
+	if err != nil {
+		log.Panic(err)
+	}
+	r.Overlap = 2
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Ridgeline"
+	p.X.Label.Text = "Value"
+	p.Add(r)
+	p.NominalY(months...)
+
+	err = p.Save(250, 250, "testdata/ridgeline.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestRidgeline(t *testing.T) {
+	checkPlot(ExampleRidgeline, t, "ridgeline.png")
+}
+
+func TestDensity(t *testing.T) {
+	d := density([]float64{-1, 0, 0.5, 2, 3}, 201)
+	var area float64
+	for i := 1; i < len(d); i++ {
+		area += (d[i].X - d[i-1].X) * (d[i].Y + d[i-1].Y) / 2
+	}
+	// The samples span three bandwidths beyond the
+	// data, so almost all of the mass is included.
+	if math.Abs(area-1) > 0.01 {
+		t.Errorf("unexpected density mass: got:%v want:1", area)
+	}
+	if d[0].Y > 0.01 || d[len(d)-1].Y > 0.01 {
+		t.Errorf("unexpected density at ends: %v, %v", d[0].Y, d[len(d)-1].Y)
+	}
+}
+
+func TestRidgelineHistograms(t *testing.T) {
+	h := &Histogram{Bins: []HistogramBin{{0, 1, 2}, {1, 2, 4}}}
+	r, err := NewRidgelineHistograms([]*Histogram{h, h})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := XYs{{0, 0}, {0, 2}, {1, 2}, {1, 4}, {2, 4}, {2, 0}}
+	for i, p := range r.Curves[0] {
+		if p != want[i] {
+			t.Errorf("unexpected curve point %d: got:%v want:%v", i, p, want[i])
+		}
+	}
+
+	xmin, xmax, ymin, ymax := r.DataRange()
+	if xmin != 0 || xmax != 2 || ymin != 0 || ymax != 1+r.Overlap {
+		t.Errorf("unexpected data range: got:[%v, %v]×[%v, %v] want:[0, 2]×[0, %v]",
+			xmin, xmax, ymin, ymax, 1+r.Overlap)
+	}
+}
@@ -0,0 +1,409 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// SankeyNode is a node of a Sankey diagram.
+type SankeyNode struct {
+	// Label is the label of the node.
+	Label string
+
+	// Column is the column in which the node
+	// is drawn, at the X value of the column.
+	Column int
+
+	// Color is the fill color of the node and,
+	// unless a link has its own color, of the
+	// links leaving the node.
+	Color color.Color
+}
+
+// SankeyLink is a weighted link between
+// two nodes of a Sankey diagram.
+type SankeyLink struct {
+	// Source and Target are the indices
+	// of the nodes joined by the link. The
+	// column of the target must be greater
+	// than the column of the source.
+	Source, Target int
+
+	// Value is the weight of the link,
+	// which must be positive.
+	Value float64
+
+	// Color is the fill color of the link. If it
+	// is nil, the color of the source node is used
+	// with the opacity of the diagram's LinkAlpha.
+	Color color.Color
+}
+
+// Sankey implements the Plotter interface, drawing a Sankey
+// diagram of flows between nodes arranged in columns. The
+// height of each node is proportional to the greater of its
+// incoming and outgoing flows, and each link is drawn as a band
+// with the height of its value. The Y values of the diagram
+// are in the units of the link values.
+//
+// The vertical positions of the nodes are found by iterative
+// relaxation, moving each node toward the weighted center of
+// the nodes it is linked to, which tends to reduce crossings
+// of the links.
+type Sankey struct {
+
+	// NodeWidth is the width of the nodes.
+	NodeWidth vg.Length
+
+	// LineStyle is the style of the outline of
+	// the nodes. A zero-width style draws no
+	// outline.
+	LineStyle draw.LineStyle
+
+	// LinkAlpha is the opacity of links drawn
+	// in the color of their source nodes.
+	LinkAlpha float64
+
+	// TextStyle is the style of the node labels,
+	// which are drawn beside the nodes, to the
+	// right except in the last column.
+	TextStyle draw.TextStyle
+
+	// nodes and links are copies of the nodes
+	// and links of the diagram, which are laid
+	// out on construction.
+	nodes []SankeyNode
+	links []SankeyLink
+
+	// values and bottoms hold the heights
+	// and the positions of the nodes.
+	values, bottoms []float64
+
+	// sourceBottoms and targetBottoms hold the
+	// positions of the ends of each link.
+	sourceBottoms, targetBottoms []float64
+
+	// height is the height of the diagram.
+	height float64
+
+	// columns is the number of columns.
+	columns int
+}
+
+// NewSankey returns a Sankey diagram of the nodes and links,
+// laid out with the given vertical gap between the nodes in
+// a column, as a fraction of the diagram height, and number
+// of relaxation iterations.
+func NewSankey(nodes []SankeyNode, links []SankeyLink, gap float64, iterations int) (*Sankey, error) {
+	if len(nodes) == 0 {
+		return nil, ErrNoData
+	}
+	if gap < 0 || gap >= 1 {
+		return nil, errors.New("Sankey gap not in [0, 1)")
+	}
+	for _, n := range nodes {
+		if n.Column < 0 {
+			return nil, errors.New("Negative Sankey column")
+		}
+	}
+	for _, l := range links {
+		if l.Source < 0 || l.Source >= len(nodes) || l.Target < 0 || l.Target >= len(nodes) {
+			return nil, errors.New("Sankey link node out of range")
+		}
+		if nodes[l.Target].Column <= nodes[l.Source].Column {
+			return nil, errors.New("Sankey link does not go forward")
+		}
+		if err := CheckFloats(l.Value); err != nil {
+			return nil, err
+		}
+		if l.Value <= 0 {
+			return nil, errors.New("Non-positive Sankey link value")
+		}
+	}
+
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	s := &Sankey{
+		nodes:     append([]SankeyNode(nil), nodes...),
+		links:     append([]SankeyLink(nil), links...),
+		NodeWidth: vg.Points(10),
+		LineStyle: DefaultLineStyle,
+		LinkAlpha: 0.5,
+		TextStyle: draw.TextStyle{Font: fnt},
+	}
+	s.LineStyle.Width = 0
+	s.layout(gap, iterations)
+	return s, nil
+}
+
+// layout sets the positions of the nodes and links.
+func (s *Sankey) layout(gap float64, iterations int) {
+	in := make([]float64, len(s.nodes))
+	out := make([]float64, len(s.nodes))
+	for _, l := range s.links {
+		out[l.Source] += l.Value
+		in[l.Target] += l.Value
+	}
+	s.values = make([]float64, len(s.nodes))
+	for i := range s.nodes {
+		s.values[i] = math.Max(in[i], out[i])
+		if s.nodes[i].Column >= s.columns {
+			s.columns = s.nodes[i].Column + 1
+		}
+	}
+
+	cols := make([][]int, s.columns)
+	for i, n := range s.nodes {
+		cols[n.Column] = append(cols[n.Column], i)
+	}
+
+	// The height of the diagram is that of the tallest
+	// column, including its gaps.
+	var maxCount int
+	var maxTotal float64
+	for _, col := range cols {
+		var total float64
+		for _, i := range col {
+			total += s.values[i]
+		}
+		maxTotal = math.Max(maxTotal, total)
+		if len(col) > maxCount {
+			maxCount = len(col)
+		}
+	}
+	if maxTotal == 0 {
+		maxTotal = 1
+	}
+	var g float64
+	if maxCount > 1 {
+		g = gap * maxTotal / ((1 - gap) * float64(maxCount-1))
+	}
+	s.height = maxTotal + g*float64(maxCount-1)
+
+	// Stack the nodes of each column from the top,
+	// in the order in which they are given.
+	s.bottoms = make([]float64, len(s.nodes))
+	for _, col := range cols {
+		y := s.height
+		for _, i := range col {
+			y -= s.values[i]
+			s.bottoms[i] = y
+			y -= g
+		}
+		s.resolve(col, g)
+	}
+
+	center := func(i int) float64 { return s.bottoms[i] + s.values[i]/2 }
+	relax := func(col []int, alpha float64, incoming bool) {
+		for _, i := range col {
+			var sum, weight float64
+			for _, l := range s.links {
+				switch {
+				case incoming && l.Target == i:
+					sum += l.Value * center(l.Source)
+				case !incoming && l.Source == i:
+					sum += l.Value * center(l.Target)
+				default:
+					continue
+				}
+				weight += l.Value
+			}
+			if weight > 0 {
+				s.bottoms[i] += (sum/weight - center(i)) * alpha
+			}
+		}
+		s.resolve(col, g)
+	}
+	alpha := 1.0
+	for k := 0; k < iterations; k++ {
+		alpha *= 0.99
+		for c := 1; c < len(cols); c++ {
+			relax(cols[c], alpha, true)
+		}
+		for c := len(cols) - 2; c >= 0; c-- {
+			relax(cols[c], alpha, false)
+		}
+	}
+
+	// Stack the links at each node in the order of
+	// the centers of the nodes at their other ends.
+	s.sourceBottoms = make([]float64, len(s.links))
+	s.targetBottoms = make([]float64, len(s.links))
+	bySource := make([][]int, len(s.nodes))
+	byTarget := make([][]int, len(s.nodes))
+	for k, l := range s.links {
+		bySource[l.Source] = append(bySource[l.Source], k)
+		byTarget[l.Target] = append(byTarget[l.Target], k)
+	}
+	for i := range s.nodes {
+		sort.Stable(byKey{bySource[i], func(k int) float64 { return center(s.links[k].Target) }})
+		y := s.bottoms[i]
+		for _, k := range bySource[i] {
+			s.sourceBottoms[k] = y
+			y += s.links[k].Value
+		}
+		sort.Stable(byKey{byTarget[i], func(k int) float64 { return center(s.links[k].Source) }})
+		y = s.bottoms[i]
+		for _, k := range byTarget[i] {
+			s.targetBottoms[k] = y
+			y += s.links[k].Value
+		}
+	}
+}
+
+// resolve moves the nodes of a column so that they
+// are separated by at least the gap g and lie within
+// the height of the diagram.
+func (s *Sankey) resolve(col []int, g float64) {
+	if len(col) == 0 {
+		return
+	}
+	sort.Stable(byKey{col, func(i int) float64 { return s.bottoms[i] }})
+	y := 0.0
+	for _, i := range col {
+		if s.bottoms[i] < y {
+			s.bottoms[i] = y
+		}
+		y = s.bottoms[i] + s.values[i] + g
+	}
+	y = s.height
+	for k := len(col) - 1; k >= 0; k-- {
+		i := col[k]
+		if top := s.bottoms[i] + s.values[i]; top > y {
+			s.bottoms[i] -= top - y
+		}
+		y = s.bottoms[i] - g
+	}
+}
+
+// byKey sorts indices by a key.
+type byKey struct {
+	idx []int
+	key func(int) float64
+}
+
+func (b byKey) Len() int           { return len(b.idx) }
+func (b byKey) Less(i, j int) bool { return b.key(b.idx[i]) < b.key(b.idx[j]) }
+func (b byKey) Swap(i, j int)      { b.idx[i], b.idx[j] = b.idx[j], b.idx[i] }
+
+// linkColor returns the fill color of link k.
+func (s *Sankey) linkColor(k int) color.Color {
+	l := s.links[k]
+	if l.Color != nil {
+		return l.Color
+	}
+	c := s.nodes[l.Source].Color
+	if c == nil {
+		c = color.Gray{Y: 128}
+	}
+	nc := color.NRGBAModel.Convert(c).(color.NRGBA)
+	nc.A = uint8(float64(nc.A) * s.LinkAlpha)
+	return nc
+}
+
+// bezier returns n+1 points along the cubic Bézier curve
+// with end points p0 and p3 and control points p1 and p2.
+func bezier(p0, p1, p2, p3 vg.Point, n int) []vg.Point {
+	pts := make([]vg.Point, n+1)
+	for i := range pts {
+		t := vg.Length(i) / vg.Length(n)
+		u := 1 - t
+		a, b, c, d := u*u*u, 3*u*u*t, 3*u*t*t, t*t*t
+		pts[i] = vg.Point{
+			X: a*p0.X + b*p1.X + c*p2.X + d*p3.X,
+			Y: a*p0.Y + b*p1.Y + c*p2.Y + d*p3.Y,
+		}
+	}
+	return pts
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+// The links are drawn as bands bounded by cubic Bézier curves,
+// approximated by polylines since vg.Path has no curves.
+func (s *Sankey) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	w := s.NodeWidth / 2
+
+	for k, l := range s.links {
+		x0 := trX(float64(s.nodes[l.Source].Column)) + w
+		x1 := trX(float64(s.nodes[l.Target].Column)) - w
+		xm := (x0 + x1) / 2
+		n := int((x1 - x0) / smoothStep)
+		if n < 1 {
+			n = 1
+		} else if n > maxSmoothSamples {
+			n = maxSmoothSamples
+		}
+		sTop, tTop := trY(s.sourceBottoms[k]+l.Value), trY(s.targetBottoms[k]+l.Value)
+		sBot, tBot := trY(s.sourceBottoms[k]), trY(s.targetBottoms[k])
+		top := bezier(
+			vg.Point{X: x0, Y: sTop}, vg.Point{X: xm, Y: sTop},
+			vg.Point{X: xm, Y: tTop}, vg.Point{X: x1, Y: tTop}, n)
+		bot := bezier(
+			vg.Point{X: x1, Y: tBot}, vg.Point{X: xm, Y: tBot},
+			vg.Point{X: xm, Y: sBot}, vg.Point{X: x0, Y: sBot}, n)
+		c.FillPolygon(s.linkColor(k), c.ClipPolygonXY(append(top, bot...)))
+	}
+
+	for i, n := range s.nodes {
+		x := trX(float64(n.Column))
+		y0, y1 := trY(s.bottoms[i]), trY(s.bottoms[i]+s.values[i])
+		pts := []vg.Point{{X: x - w, Y: y0}, {X: x + w, Y: y0}, {X: x + w, Y: y1}, {X: x - w, Y: y1}}
+		clr := n.Color
+		if clr == nil {
+			clr = color.Gray{Y: 128}
+		}
+		c.FillPolygon(clr, c.ClipPolygonXY(pts))
+		if s.LineStyle.Width != 0 {
+			c.StrokeLines(s.LineStyle, c.ClipLinesXY(append(pts, pts[0]))...)
+		}
+
+		if n.Label == "" {
+			continue
+		}
+		sty := s.TextStyle
+		sty.YAlign = draw.YCenter
+		pt := vg.Point{Y: (y0 + y1) / 2}
+		if n.Column == s.columns-1 {
+			sty.XAlign = draw.XRight
+			pt.X = x - w - s.TextStyle.Width(" ")
+		} else {
+			pt.X = x + w + s.TextStyle.Width(" ")
+		}
+		c.FillText(sty, pt, n.Label)
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (s *Sankey) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return 0, float64(s.columns - 1), 0, s.height
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface, reserving
+// space for the widths of the nodes.
+func (s *Sankey) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, len(s.nodes))
+	for i, n := range s.nodes {
+		bs[i].X = plt.X.Norm(float64(n.Column))
+		bs[i].Y = plt.Y.Norm(s.bottoms[i] + s.values[i]/2)
+		bs[i].Rectangle = vg.Rectangle{
+			Min: vg.Point{X: -s.NodeWidth / 2},
+			Max: vg.Point{X: s.NodeWidth / 2},
+		}
+	}
+	return bs
+}
@@ -0,0 +1,143 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// ExampleSankey draws a Sankey diagram of requests
+// flowing between the services of a system.
+func ExampleSankey() {
+	blue := color.RGBA{R: 51, G: 102, B: 204, A: 255}
+	orange := color.RGBA{R: 230, G: 140, B: 30, A: 255}
+	green := color.RGBA{R: 51, G: 153, B: 51, A: 255}
+	gray := color.RGBA{R: 110, G: 110, B: 110, A: 255}
+	nodes := []SankeyNode{
+		{Label: "Web", Column: 0, Color: blue},
+		{Label: "Mobile", Column: 0, Color: orange},
+		{Label: "Partners", Column: 0, Color: green},
+		{Label: "Gateway", Column: 1, Color: gray},
+		{Label: "Batch", Column: 1, Color: gray},
+		{Label: "Search", Column: 2, Color: gray},
+		{Label: "Orders", Column: 2, Color: gray},
+		{Label: "Users", Column: 2, Color: gray},
+		{Label: "Cache", Column: 3, Color: gray},
+		{Label: "Database", Column: 3, Color: gray},
+	}
+	links := []SankeyLink{
+		{Source: 0, Target: 3, Value: 50},
+		{Source: 1, Target: 3, Value: 30},
+		{Source: 2, Target: 4, Value: 15},
+		{Source: 2, Target: 3, Value: 5},
+		{Source: 3, Target: 5, Value: 35},
+		{Source: 3, Target: 6, Value: 20},
+		{Source: 3, Target: 7, Value: 30},
+		{Source: 4, Target: 6, Value: 15},
+		{Source: 5, Target: 8, Value: 30},
+		{Source: 5, Target: 9, Value: 5},
+		{Source: 6, Target: 9, Value: 35},
+		{Source: 7, Target: 8, Value: 10},
+		{Source: 7, Target: 9, Value: 20},
+	}
+	s, err := NewSankey(nodes, links, 0.1, 32)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Request flow"
+	p.HideAxes()
+	p.Add(s)
+
+	err = p.Save(300, 200, "testdata/sankey.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestSankey(t *testing.T) {
+	checkPlot(ExampleSankey, t, "sankey.png")
+}
+
+func TestSankeyLayout(t *testing.T) {
+	// The links cross when the nodes
+	// are stacked in the given order.
+	nodes := []SankeyNode{
+		{Column: 0}, {Column: 0},
+		{Column: 1}, {Column: 1},
+	}
+	links := []SankeyLink{
+		{Source: 0, Target: 3, Value: 2},
+		{Source: 1, Target: 2, Value: 2},
+	}
+
+	s, err := NewSankey(nodes, links, 0.2, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !(s.bottoms[0] > s.bottoms[1] && s.bottoms[2] > s.bottoms[3]) {
+		t.Errorf("unexpected initial layout: %v", s.bottoms)
+	}
+	if s.height != 5 {
+		t.Errorf("unexpected height: got:%v want:5", s.height)
+	}
+
+	s, err = NewSankey(nodes, links, 0.2, 32)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if (s.bottoms[0] > s.bottoms[1]) != (s.bottoms[3] > s.bottoms[2]) {
+		t.Errorf("links cross after relaxation: %v", s.bottoms)
+	}
+	for i, b := range s.bottoms {
+		if b < 0 || b+s.values[i] > s.height+1e-12 {
+			t.Errorf("node %d outside diagram: [%v, %v]", i, b, b+s.values[i])
+		}
+	}
+	for k, l := range s.links {
+		if s.sourceBottoms[k] != s.bottoms[l.Source] || s.targetBottoms[k] != s.bottoms[l.Target] {
+			t.Errorf("unexpected ends of link %d", k)
+		}
+	}
+
+	_, err = NewSankey(nodes, []SankeyLink{{Source: 2, Target: 0, Value: 1}}, 0.1, 0)
+	if err == nil {
+		t.Error("expected error for backward link")
+	}
+
+	// Changes to the nodes and links after
+	// construction do not affect the diagram.
+	links[0].Target = 7
+	nodes[3].Column = 0
+	if s.links[0].Target != 3 || s.nodes[3].Column != 1 {
+		t.Errorf("diagram shares the caller's slices: links:%v nodes:%v", s.links, s.nodes)
+	}
+}
+
+func TestBezier(t *testing.T) {
+	pts := bezier(vg.Point{X: 0, Y: 0}, vg.Point{X: 1, Y: 0}, vg.Point{X: 1, Y: 1}, vg.Point{X: 2, Y: 1}, 4)
+	if len(pts) != 5 {
+		t.Fatalf("unexpected number of points: got:%d want:5", len(pts))
+	}
+	start, mid, end := vg.Point{X: 0, Y: 0}, vg.Point{X: 1, Y: 0.5}, vg.Point{X: 2, Y: 1}
+	if pts[0] != start || pts[2] != mid || pts[4] != end {
+		t.Errorf("unexpected curve: %v", pts)
+	}
+	for i := 1; i < len(pts); i++ {
+		if pts[i].X < pts[i-1].X || pts[i].Y < pts[i-1].Y || math.IsNaN(float64(pts[i].X)) {
+			t.Errorf("curve is not monotone: %v", pts)
+		}
+	}
+}
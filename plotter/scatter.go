@@ -5,7 +5,11 @@
 package plotter
 
 import (
+	"errors"
+	"sort"
+
 	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
 	"github.com/gonum/plot/vg"
 	"github.com/gonum/plot/vg/draw"
 )
@@ -19,6 +23,18 @@ type Scatter struct {
 	// GlyphStyle is the style of the glyphs drawn
 	// at each point.
 	draw.GlyphStyle
+
+	// GlyphStyleFunc, if not nil, returns the style
+	// of the glyph drawn at the point with index i,
+	// in place of GlyphStyle.
+	GlyphStyleFunc func(i int) draw.GlyphStyle
+
+	// Decimation specifies how the points are
+	// reduced before drawing. By default every
+	// point is drawn. When decimating, the points
+	// are sorted by X before they are reduced, so
+	// they need not be given in order.
+	Decimation Decimation
 }
 
 // NewScatter returns a Scatter that uses the
@@ -34,30 +50,172 @@ func NewScatter(xys XYer) (*Scatter, error) {
 	}, err
 }
 
+// NewScatterWithGaps returns a Scatter that uses the default
+// glyph style. Points with a NaN x or y value are accepted and
+// are not drawn.
+func NewScatterWithGaps(xys XYer) (*Scatter, error) {
+	data, err := CopyXYsWithGaps(xys)
+	if err != nil {
+		return nil, err
+	}
+	return &Scatter{
+		XYs:        data,
+		GlyphStyle: DefaultGlyphStyle,
+	}, nil
+}
+
 // Plot draws the Scatter, implementing the plot.Plotter
-// interface.
+// interface. Points with a NaN x or y value are not drawn.
 func (pts *Scatter) Plot(c draw.Canvas, plt *plot.Plot) {
 	trX, trY := plt.Transforms(&c)
-	for _, p := range pts.XYs {
-		c.DrawGlyph(pts.GlyphStyle, vg.Point{X: trX(p.X), Y: trY(p.Y)})
+	var (
+		ps  []vg.Point
+		idx []int
+	)
+	for i, p := range pts.XYs {
+		if missing(p.X, p.Y) {
+			continue
+		}
+		ps = append(ps, vg.Point{X: trX(p.X), Y: trY(p.Y)})
+		idx = append(idx, i)
+	}
+	if pts.Decimation != NoDecimation {
+		// Decimation requires the points to be ordered by X.
+		order := all(len(ps))
+		sort.Stable(byKey{order, func(i int) float64 { return float64(ps[i].X) }})
+		sorted := make([]vg.Point, len(ps))
+		sortedIdx := make([]int, len(ps))
+		for i, j := range order {
+			sorted[i], sortedIdx[i] = ps[j], idx[j]
+		}
+		ps, idx = sorted, sortedIdx
+	}
+	for _, i := range decimate(c, ps, pts.Decimation) {
+		c.DrawGlyph(pts.style(idx[i]), ps[i])
+	}
+}
+
+// style returns the glyph style of the point with index i.
+func (pts *Scatter) style(i int) draw.GlyphStyle {
+	if pts.GlyphStyleFunc == nil {
+		return pts.GlyphStyle
+	}
+	return pts.GlyphStyleFunc(i)
+}
+
+// ColorBy sets the color of each glyph to the color in the
+// palette p corresponding to the value with the same index in
+// vs, when p is scaled uniformly across the range of vs. Other
+// glyph style mappings already set on pts are retained.
+func (pts *Scatter) ColorBy(vs Valuer, p palette.Palette) error {
+	if err := pts.checkMapping(vs); err != nil {
+		return err
+	}
+	pal := p.Colors()
+	if len(pal) == 0 {
+		return errors.New("Empty palette")
+	}
+	cpy, err := CopyValues(vs)
+	if err != nil {
+		return err
+	}
+	min, max := Range(cpy)
+	pts.mapStyle(func(i int, sty *draw.GlyphStyle) {
+		sty.Color = paletteColor(pal, cpy[i], min, max, nil, nil)
+	})
+	return nil
+}
+
+// SizeBy sets the radius of each glyph by interpolating
+// linearly between min and max according to the value with
+// the same index in vs over the range of vs. Other glyph
+// style mappings already set on pts are retained.
+func (pts *Scatter) SizeBy(vs Valuer, min, max vg.Length) error {
+	if err := pts.checkMapping(vs); err != nil {
+		return err
+	}
+	if min > max {
+		return errors.New("Min glyph radius is greater than the max radius")
+	}
+	cpy, err := CopyValues(vs)
+	if err != nil {
+		return err
+	}
+	vmin, vmax := Range(cpy)
+	pts.mapStyle(func(i int, sty *draw.GlyphStyle) {
+		sty.Radius = max
+		if vmin != vmax {
+			t := (cpy[i] - vmin) / (vmax - vmin)
+			sty.Radius = min + vg.Length(t)*(max-min)
+		}
+	})
+	return nil
+}
+
+// ShapeBy sets the shape of each glyph to shape(cats[i]),
+// where cats holds a category for each point. The function
+// plotutil.Shape may be used as shape. Other glyph style
+// mappings already set on pts are retained.
+func (pts *Scatter) ShapeBy(cats []int, shape func(int) draw.GlyphDrawer) error {
+	if len(cats) != len(pts.XYs) {
+		return errors.New("Number of categories does not match the number of points")
+	}
+	cpy := append([]int(nil), cats...)
+	pts.mapStyle(func(i int, sty *draw.GlyphStyle) {
+		sty.Shape = shape(cpy[i])
+	})
+	return nil
+}
+
+// mapStyle sets the GlyphStyleFunc of pts to apply f to the
+// glyph style given by any previous GlyphStyleFunc, or to
+// GlyphStyle if there is none.
+func (pts *Scatter) mapStyle(f func(i int, sty *draw.GlyphStyle)) {
+	prev := pts.GlyphStyleFunc
+	pts.GlyphStyleFunc = func(i int) draw.GlyphStyle {
+		sty := pts.GlyphStyle
+		if prev != nil {
+			sty = prev(i)
+		}
+		f(i, &sty)
+		return sty
+	}
+}
+
+// checkMapping returns an error if the values in vs can
+// not be mapped to the points of pts.
+func (pts *Scatter) checkMapping(vs Valuer) error {
+	if vs.Len() != len(pts.XYs) {
+		return errors.New("Number of values does not match the number of points")
+	}
+	for i := 0; i < vs.Len(); i++ {
+		if err := CheckFloats(vs.Value(i)); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
 // DataRange returns the minimum and maximum
 // x and y values, implementing the plot.DataRanger
 // interface.
 func (pts *Scatter) DataRange() (xmin, xmax, ymin, ymax float64) {
-	return XYRange(pts)
+	return gapRange(pts)
 }
 
 // GlyphBoxes returns a slice of plot.GlyphBoxes,
 // implementing the plot.GlyphBoxer interface.
 func (pts *Scatter) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
-	bs := make([]plot.GlyphBox, len(pts.XYs))
+	bs := make([]plot.GlyphBox, 0, len(pts.XYs))
 	for i, p := range pts.XYs {
-		bs[i].X = plt.X.Norm(p.X)
-		bs[i].Y = plt.Y.Norm(p.Y)
-		bs[i].Rectangle = pts.GlyphStyle.Rectangle()
+		if missing(p.X, p.Y) {
+			continue
+		}
+		bs = append(bs, plot.GlyphBox{
+			X:         plt.X.Norm(p.X),
+			Y:         plt.Y.Norm(p.Y),
+			Rectangle: pts.style(i).Rectangle(),
+		})
 	}
 	return bs
 }
@@ -8,11 +8,14 @@ import (
 	"image/color"
 	"log"
 	"math/rand"
+	"reflect"
 	"testing"
 
 	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
 	"github.com/gonum/plot/vg"
 	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/recorder"
 )
 
 // ExampleScatter draws some scatter points, a line,
@@ -86,3 +89,161 @@ func ExampleScatter() {
 func TestScatter(t *testing.T) {
 	checkPlot(ExampleScatter, t, "scatter.png")
 }
+
+// ExampleScatter_mapped draws a single scatter with the color,
+// size and shape of each point mapped from its data.
+func ExampleScatter_mapped() {
+	rnd := rand.New(rand.NewSource(1))
+
+	n := 40
+	pts := make(XYs, n)
+	zs := make(Values, n)
+	cats := make([]int, n)
+	for i := range pts {
+		pts[i].X = rnd.NormFloat64()
+		pts[i].Y = rnd.NormFloat64()
+		zs[i] = pts[i].X + pts[i].Y
+		cats[i] = i % 3
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Mapped scatter"
+
+	s, err := NewScatter(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	// The shapes could equally be given by plotutil.Shape.
+	shapes := []draw.GlyphDrawer{draw.CircleGlyph{}, draw.BoxGlyph{}, draw.PyramidGlyph{}}
+	err = s.ShapeBy(cats, func(c int) draw.GlyphDrawer { return shapes[c] })
+	if err != nil {
+		log.Panic(err)
+	}
+	err = s.ColorBy(zs, palette.Rainbow(10, palette.Blue, palette.Red, 1, 1, 1))
+	if err != nil {
+		log.Panic(err)
+	}
+	err = s.SizeBy(zs, vg.Points(2), vg.Points(6))
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Add(s)
+
+	err = p.Save(200, 200, "testdata/scatterMapped.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestScatterMapped(t *testing.T) {
+	checkPlot(ExampleScatter_mapped, t, "scatterMapped.png")
+}
+
+func TestScatterGlyphBoxes(t *testing.T) {
+	s, err := NewScatter(XYs{{0, 0}, {1, 1}, {2, 2}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = s.SizeBy(Values{0, 1, 2}, 1, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s.GlyphStyle.Shape = draw.BoxGlyph{}
+	err = s.ShapeBy([]int{0, 1, 0}, func(c int) draw.GlyphDrawer {
+		if c == 0 {
+			return draw.CircleGlyph{}
+		}
+		return draw.BoxGlyph{}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(s)
+	for i, b := range s.GlyphBoxes(p) {
+		want := vg.Length(i + 1)
+		if b.Max.X != want || b.Min.X != -want {
+			t.Errorf("unexpected glyph box for point %d: got:%v want radius %v", i, b.Rectangle, want)
+		}
+	}
+	want := []draw.GlyphDrawer{draw.CircleGlyph{}, draw.BoxGlyph{}, draw.CircleGlyph{}}
+	for i := range s.XYs {
+		if got := s.GlyphStyleFunc(i).Shape; got != want[i] {
+			t.Errorf("unexpected shape for point %d: got:%T want:%T", i, got, want[i])
+		}
+	}
+
+	if err := s.ColorBy(Values{1, 2}, palette.Heat(4, 1)); err == nil {
+		t.Error("expected error for mismatched value count")
+	}
+}
+
+func TestScatterMappingCopies(t *testing.T) {
+	s, err := NewScatter(XYs{{0, 0}, {1, 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	vs := Values{0, 1}
+	if err = s.SizeBy(vs, 1, 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	vs[1] = 10
+	if r := s.GlyphStyleFunc(1).Radius; r != 3 {
+		t.Errorf("unexpected radius after changing values: got:%v want:3", r)
+	}
+}
+
+func TestScatterDecimationUnordered(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	ordered := make(XYs, 200)
+	for i := range ordered {
+		ordered[i].X = float64(i) / float64(len(ordered))
+		ordered[i].Y = rnd.NormFloat64()
+	}
+	perm := rnd.Perm(len(ordered))
+	shuffled := make(XYs, len(ordered))
+	for i, j := range perm {
+		shuffled[i] = ordered[j]
+	}
+
+	// drawn returns the indices in ordered of the
+	// points drawn by a decimated scatter of xys,
+	// which holds the points of ordered at the
+	// indices given by index.
+	drawn := func(xys XYs, index func(int) int) map[int]bool {
+		s, err := NewScatter(xys)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		s.Decimation = MinMax
+		got := make(map[int]bool)
+		s.GlyphStyleFunc = func(i int) draw.GlyphStyle {
+			got[index(i)] = true
+			return s.GlyphStyle
+		}
+		p, err := plot.New()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p.Add(s)
+		var r recorder.Canvas
+		s.Plot(draw.Canvas{Canvas: &r, Rectangle: vg.Rectangle{Max: vg.Point{X: 10, Y: 10}}}, p)
+		return got
+	}
+
+	want := drawn(ordered, func(i int) int { return i })
+	got := drawn(shuffled, func(i int) int { return perm[i] })
+	if len(want) >= len(ordered) {
+		t.Fatalf("points not decimated: %d of %d drawn", len(want), len(ordered))
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected points drawn from unordered input: got %d points want %d", len(got), len(want))
+	}
+}
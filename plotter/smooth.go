@@ -0,0 +1,196 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+
+	"github.com/gonum/plot/vg"
+)
+
+// Smoothing specifies how a Line is interpolated
+// between its points.
+//
+// The smooth curves are interpolated in data coordinates
+// as functions of X, so the points must be strictly
+// increasing in X; if they are not, straight segments are
+// drawn. vg.Path has no curve component, so the curves are
+// drawn as finely sampled polylines, which are clipped to
+// the canvas in the same way as straight lines.
+type Smoothing int
+
+const (
+	// NoSmoothing draws straight segments between points.
+	NoSmoothing Smoothing = iota
+
+	// MonotoneCubic draws a monotone cubic Hermite spline
+	// using the Fritsch–Carlson method. The curve does not
+	// overshoot the data, so it is monotone wherever the
+	// data are.
+	MonotoneCubic
+
+	// CatmullRom draws a Catmull-Rom spline, a cubic Hermite
+	// spline with the tangent at each point parallel to the
+	// line joining its neighbours.
+	CatmullRom
+
+	// NaturalCubic draws a natural cubic spline, which has
+	// continuous second derivatives and zero curvature at
+	// its ends.
+	NaturalCubic
+)
+
+// smoothStep is the approximate canvas distance
+// between samples of a smooth curve when drawn.
+const smoothStep = vg.Length(1)
+
+// maxSmoothSamples is the maximum number of samples
+// drawn between two points of a smooth curve.
+const maxSmoothSamples = 100
+
+// smooth returns the points of the curve through xys using the
+// smoothing method s. The curve between the points with indices
+// i and i+1 is sampled at samples(i) intervals, clamped to the
+// range 1 to maxSmoothSamples. The original points are included
+// in the result.
+func smooth(xys XYs, s Smoothing, samples func(i int) int) XYs {
+	if s == NoSmoothing || len(xys) < 3 {
+		return xys
+	}
+	for i := 1; i < len(xys); i++ {
+		if xys[i].X <= xys[i-1].X {
+			return xys
+		}
+	}
+
+	var m []float64
+	switch s {
+	case MonotoneCubic:
+		m = monotoneSlopes(xys)
+	case CatmullRom:
+		m = catmullRomSlopes(xys)
+	case NaturalCubic:
+		m = naturalSlopes(xys)
+	default:
+		panic("plotter: unknown smoothing")
+	}
+
+	out := XYs{xys[0]}
+	for i := 1; i < len(xys); i++ {
+		p, q := xys[i-1], xys[i]
+		n := samples(i - 1)
+		if n < 1 {
+			n = 1
+		} else if n > maxSmoothSamples {
+			n = maxSmoothSamples
+		}
+		h := q.X - p.X
+		for k := 1; k < n; k++ {
+			t := float64(k) / float64(n)
+			out = append(out, struct{ X, Y float64 }{
+				X: p.X + t*h,
+				Y: hermite(p.Y, q.Y, m[i-1]*h, m[i]*h, t),
+			})
+		}
+		out = append(out, q)
+	}
+	return out
+}
+
+// hermite returns the value at t in [0, 1] of the cubic
+// Hermite polynomial with end values y0 and y1 and end
+// tangents m0 and m1, scaled to the unit interval.
+func hermite(y0, y1, m0, m1, t float64) float64 {
+	t2 := t * t
+	t3 := t2 * t
+	return (2*t3-3*t2+1)*y0 + (t3-2*t2+t)*m0 + (-2*t3+3*t2)*y1 + (t3-t2)*m1
+}
+
+// secants returns the slopes of the segments between
+// consecutive points of xys.
+func secants(xys XYs) []float64 {
+	d := make([]float64, len(xys)-1)
+	for i := range d {
+		d[i] = (xys[i+1].Y - xys[i].Y) / (xys[i+1].X - xys[i].X)
+	}
+	return d
+}
+
+// monotoneSlopes returns the tangents at the points of xys
+// of the Fritsch–Carlson monotone cubic interpolant.
+func monotoneSlopes(xys XYs) []float64 {
+	n := len(xys)
+	d := secants(xys)
+	m := make([]float64, n)
+	m[0], m[n-1] = d[0], d[n-2]
+	for i := 1; i < n-1; i++ {
+		if d[i-1]*d[i] > 0 {
+			m[i] = (d[i-1] + d[i]) / 2
+		}
+	}
+	for i, s := range d {
+		if s == 0 {
+			m[i], m[i+1] = 0, 0
+			continue
+		}
+		a, b := m[i]/s, m[i+1]/s
+		if r := a*a + b*b; r > 9 {
+			tau := 3 / math.Sqrt(r)
+			m[i] = tau * a * s
+			m[i+1] = tau * b * s
+		}
+	}
+	return m
+}
+
+// catmullRomSlopes returns the tangents at the points of xys
+// of the Catmull-Rom spline. The end tangents are the slopes
+// of the end segments.
+func catmullRomSlopes(xys XYs) []float64 {
+	n := len(xys)
+	d := secants(xys)
+	m := make([]float64, n)
+	m[0], m[n-1] = d[0], d[n-2]
+	for i := 1; i < n-1; i++ {
+		m[i] = (xys[i+1].Y - xys[i-1].Y) / (xys[i+1].X - xys[i-1].X)
+	}
+	return m
+}
+
+// naturalSlopes returns the tangents at the points of xys
+// of the natural cubic spline through the points.
+func naturalSlopes(xys XYs) []float64 {
+	n := len(xys)
+	d := secants(xys)
+	h := make([]float64, n-1)
+	for i := range h {
+		h[i] = xys[i+1].X - xys[i].X
+	}
+
+	// Solve the tridiagonal system for the second
+	// derivatives, which are zero at the ends.
+	sec := make([]float64, n)
+	diag := make([]float64, n)
+	rhs := make([]float64, n)
+	for i := 1; i < n-1; i++ {
+		diag[i] = 2 * (h[i-1] + h[i])
+		rhs[i] = 6 * (d[i] - d[i-1])
+	}
+	for i := 2; i < n-1; i++ {
+		w := h[i-1] / diag[i-1]
+		diag[i] -= w * h[i-1]
+		rhs[i] -= w * rhs[i-1]
+	}
+	for i := n - 2; i > 0; i-- {
+		sec[i] = (rhs[i] - h[i]*sec[i+1]) / diag[i]
+	}
+
+	m := make([]float64, n)
+	for i := 0; i < n-1; i++ {
+		m[i] = d[i] - h[i]*(2*sec[i]+sec[i+1])/6
+	}
+	m[n-1] = d[n-2] + h[n-2]*(sec[n-2]+2*sec[n-1])/6
+	return m
+}
@@ -0,0 +1,119 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"math"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// ExampleLine_smoothed draws the same points joined
+// by each of the smoothing methods.
+func ExampleLine_smoothed() {
+	pts := XYs{{0, 0}, {1, 0.2}, {2, 0.1}, {3, 2}, {4, 2.1}, {5, 2}, {6, 0.5}, {7, 0.6}}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Smoothed lines"
+	p.Legend.Left = true
+	p.Legend.Top = true
+
+	s, err := NewScatter(pts)
+	if err != nil {
+		log.Panic(err)
+	}
+	s.Radius = vg.Points(2)
+	p.Add(s)
+
+	for i, m := range []struct {
+		name  string
+		s     Smoothing
+		color color.Color
+	}{
+		{"monotone", MonotoneCubic, color.RGBA{R: 220, A: 255}},
+		{"Catmull-Rom", CatmullRom, color.RGBA{G: 160, A: 255}},
+		{"natural", NaturalCubic, color.RGBA{B: 220, A: 255}},
+	} {
+		l, err := NewLine(pts)
+		if err != nil {
+			log.Panic(err)
+		}
+		l.Smoothing = m.s
+		l.Color = m.color
+		l.Width = vg.Points(1)
+		l.Dashes = []vg.Length{vg.Points(float64(6 - 2*i)), vg.Points(2)}
+		p.Add(l)
+		p.Legend.Add(m.name, l)
+	}
+
+	err = p.Save(200, 200, "testdata/lineSmoothed.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestLineSmoothed(t *testing.T) {
+	checkPlot(ExampleLine_smoothed, t, "lineSmoothed.png")
+}
+
+func TestSmooth(t *testing.T) {
+	samples := func(int) int { return 10 }
+
+	// All methods pass through the points, and
+	// reproduce straight lines exactly.
+	line := XYs{{0, 1}, {1, 3}, {3, 7}, {4, 9}}
+	for _, s := range []Smoothing{MonotoneCubic, CatmullRom, NaturalCubic} {
+		got := smooth(line, s, samples)
+		if len(got) <= len(line) {
+			t.Errorf("no samples added for smoothing %d", s)
+		}
+		for _, p := range got {
+			if want := 2*p.X + 1; math.Abs(p.Y-want) > 1e-12 {
+				t.Errorf("unexpected value for smoothing %d at x=%v: got:%v want:%v", s, p.X, p.Y, want)
+			}
+		}
+	}
+
+	// The monotone curve does not overshoot a step.
+	step := XYs{{0, 0}, {1, 0}, {2, 1}, {3, 1}}
+	prev := math.Inf(-1)
+	for _, p := range smooth(step, MonotoneCubic, samples) {
+		if p.Y < prev || p.Y < 0 || p.Y > 1 {
+			t.Errorf("monotone curve overshoots at x=%v: y=%v", p.X, p.Y)
+		}
+		prev = p.Y
+	}
+
+	// The slopes of the natural cubic spline through a peak.
+	ys := naturalSlopes(XYs{{0, 0}, {1, 1}, {2, 0}})
+	if want := []float64{1.5, 0, -1.5}; !equalFloats(ys, want) {
+		t.Errorf("unexpected natural spline slopes: got:%v want:%v", ys, want)
+	}
+
+	// Points not increasing in X are drawn straight.
+	unsorted := XYs{{0, 0}, {2, 1}, {1, 2}}
+	if got := smooth(unsorted, CatmullRom, samples); len(got) != len(unsorted) {
+		t.Errorf("unexpected smoothing of unsorted points: got %d points want %d", len(got), len(unsorted))
+	}
+}
+
+func equalFloats(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > 1e-12 {
+			return false
+		}
+	}
+	return true
+}
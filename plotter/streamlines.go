@@ -0,0 +1,351 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Streamlines implements the Plotter interface, drawing
+// curves that follow the flow of a gridded vector field.
+type Streamlines struct {
+	// Lines holds the streamlines in data coordinates.
+	// The points of each line are ordered in the
+	// direction of the flow.
+	Lines []XYs
+
+	// LineStyle is the style of the streamlines.
+	LineStyle draw.LineStyle
+
+	// ArrowSize is the length of the arrow head drawn
+	// at the middle of each streamline. No arrow heads
+	// are drawn if ArrowSize is zero.
+	ArrowSize vg.Length
+
+	// xmin, xmax, ymin and ymax are the
+	// extent of the vector field.
+	xmin, xmax, ymin, ymax float64
+}
+
+// NewStreamlines returns a new Streamlines plotter for the
+// vector field g. The streamlines are integrated so that
+// they do not come closer to each other than about 1/30th
+// of the extent of the field divided by density. The grid
+// coordinates of g must be increasing.
+func NewStreamlines(g GridXYUV, density float64) (*Streamlines, error) {
+	if density <= 0 {
+		return nil, errors.New("Density parameter was not positive")
+	}
+	f, err := newVectorField(g)
+	if err != nil {
+		return nil, err
+	}
+	s := &Streamlines{
+		LineStyle: DefaultLineStyle,
+		ArrowSize: vg.Points(5),
+		xmin:      f.xs[0],
+		xmax:      f.xs[len(f.xs)-1],
+		ymin:      f.ys[0],
+		ymax:      f.ys[len(f.ys)-1],
+	}
+
+	n := int(30*density + 0.5)
+	if n < 1 {
+		n = 1
+	}
+	in := &integrator{
+		field: f,
+		n:     n,
+		step:  0.2 / float64(n),
+		mask:  make([]bool, n*n),
+	}
+	w, h := s.xmax-s.xmin, s.ymax-s.ymin
+	for _, cell := range spiral(n) {
+		if in.mask[cell] {
+			continue
+		}
+		seed := point{
+			X: (float64(cell%n) + 0.5) / float64(n),
+			Y: (float64(cell/n) + 0.5) / float64(n),
+		}
+		line := in.streamline(seed)
+		if line == nil {
+			continue
+		}
+		xys := make(XYs, len(line))
+		for i, p := range line {
+			xys[i].X = s.xmin + p.X*w
+			xys[i].Y = s.ymin + p.Y*h
+		}
+		s.Lines = append(s.Lines, xys)
+	}
+	return s, nil
+}
+
+// vectorField is a copy of a gridded vector field that
+// can be sampled at any location within the grid.
+type vectorField struct {
+	xs, ys []float64
+	us, vs []float64
+}
+
+func newVectorField(g GridXYUV) (*vectorField, error) {
+	c, r := g.Dims()
+	if c < 2 || r < 2 {
+		return nil, errors.New("Vector field grid must have at least two rows and columns")
+	}
+	f := &vectorField{
+		xs: make([]float64, c),
+		ys: make([]float64, r),
+		us: make([]float64, c*r),
+		vs: make([]float64, c*r),
+	}
+	for i := range f.xs {
+		f.xs[i] = g.X(i)
+	}
+	for j := range f.ys {
+		f.ys[j] = g.Y(j)
+	}
+	if !sort.Float64sAreSorted(f.xs) || !sort.Float64sAreSorted(f.ys) ||
+		f.xs[0] == f.xs[c-1] || f.ys[0] == f.ys[r-1] {
+		return nil, errors.New("Vector field grid coordinates are not increasing")
+	}
+	for j := 0; j < r; j++ {
+		for i := 0; i < c; i++ {
+			u, v := g.Vector(i, j)
+			if err := CheckFloats(u, v); err != nil {
+				return nil, err
+			}
+			f.us[j*c+i], f.vs[j*c+i] = u, v
+		}
+	}
+	return f, nil
+}
+
+// at returns the vector at (x, y) by bilinear interpolation
+// and whether the location is within the grid.
+func (f *vectorField) at(x, y float64) (u, v float64, ok bool) {
+	i, tx, ok := locate(f.xs, x)
+	if !ok {
+		return 0, 0, false
+	}
+	j, ty, ok := locate(f.ys, y)
+	if !ok {
+		return 0, 0, false
+	}
+	c := len(f.xs)
+	k := j*c + i
+	lerp := func(vals []float64) float64 {
+		bottom := vals[k]*(1-tx) + vals[k+1]*tx
+		top := vals[k+c]*(1-tx) + vals[k+c+1]*tx
+		return bottom*(1-ty) + top*ty
+	}
+	return lerp(f.us), lerp(f.vs), true
+}
+
+// locate returns the index i of the interval of the sorted
+// coordinates xs holding x, the fractional position of x
+// within the interval, and whether x is within xs.
+func locate(xs []float64, x float64) (i int, t float64, ok bool) {
+	if x < xs[0] || x > xs[len(xs)-1] {
+		return 0, 0, false
+	}
+	i = sort.SearchFloat64s(xs, x) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i > len(xs)-2 {
+		i = len(xs) - 2
+	}
+	if d := xs[i+1] - xs[i]; d != 0 {
+		t = (x - xs[i]) / d
+	}
+	return i, t, true
+}
+
+// integrator traces streamlines through a vector field in
+// coordinates normalized to the unit square. Cells of an
+// n×n mask are marked as they are crossed by streamlines
+// so that later streamlines stop before approaching them.
+type integrator struct {
+	field *vectorField
+	n     int
+	step  float64
+	mask  []bool
+}
+
+// direction returns the unit direction of the field at p
+// in normalized coordinates, or false if p is outside the
+// field or the field vanishes there.
+func (in *integrator) direction(p point) (point, bool) {
+	f := in.field
+	w, h := f.xs[len(f.xs)-1]-f.xs[0], f.ys[len(f.ys)-1]-f.ys[0]
+	u, v, ok := f.at(f.xs[0]+p.X*w, f.ys[0]+p.Y*h)
+	if !ok {
+		return point{}, false
+	}
+	u, v = u/w, v/h
+	speed := math.Hypot(u, v)
+	if speed == 0 {
+		return point{}, false
+	}
+	return point{X: u / speed, Y: v / speed}, true
+}
+
+// cell returns the index of the mask cell holding p.
+func (in *integrator) cell(p point) int {
+	i := int(p.X * float64(in.n))
+	j := int(p.Y * float64(in.n))
+	if i >= in.n {
+		i = in.n - 1
+	}
+	if j >= in.n {
+		j = in.n - 1
+	}
+	return j*in.n + i
+}
+
+// streamline returns the streamline through seed, or nil
+// if the streamline is too short to be drawn.
+func (in *integrator) streamline(seed point) []point {
+	start := in.cell(seed)
+	in.mask[start] = true
+	own := []int{start}
+
+	back := in.trace(seed, -1, &own)
+	fwd := in.trace(seed, 1, &own)
+
+	line := make([]point, 0, len(back)+len(fwd)+1)
+	for i := len(back) - 1; i >= 0; i-- {
+		line = append(line, back[i])
+	}
+	line = append(line, seed)
+	line = append(line, fwd...)
+
+	// Discard streamlines shorter than a few mask cells
+	// and make their cells available to other lines.
+	if float64(len(line)-1)*in.step < 3/float64(in.n) {
+		for _, c := range own {
+			in.mask[c] = false
+		}
+		return nil
+	}
+	return line
+}
+
+// trace integrates from p in the direction given by sign
+// using the midpoint method until the line leaves the field,
+// reaches a stagnation point or enters a marked cell. The
+// cells crossed are marked and appended to own.
+func (in *integrator) trace(p point, sign float64, own *[]int) []point {
+	var pts []point
+	cur := in.cell(p)
+	h := sign * in.step
+	for steps := int(4 / in.step); steps > 0; steps-- {
+		d1, ok := in.direction(p)
+		if !ok {
+			break
+		}
+		mid := point{X: p.X + d1.X*h/2, Y: p.Y + d1.Y*h/2}
+		d2, ok := in.direction(mid)
+		if !ok {
+			break
+		}
+		next := point{X: p.X + d2.X*h, Y: p.Y + d2.Y*h}
+		if next.X < 0 || next.X > 1 || next.Y < 0 || next.Y > 1 {
+			break
+		}
+		if c := in.cell(next); c != cur {
+			if in.mask[c] {
+				break
+			}
+			in.mask[c] = true
+			*own = append(*own, c)
+			cur = c
+		}
+		pts = append(pts, next)
+		p = next
+	}
+	return pts
+}
+
+// spiral returns the cells of an n×n grid in order from the
+// boundary of the grid inwards.
+func spiral(n int) []int {
+	cells := make([]int, 0, n*n)
+	for lo, hi := 0, n-1; lo <= hi; lo, hi = lo+1, hi-1 {
+		if lo == hi {
+			cells = append(cells, lo*n+lo)
+			break
+		}
+		for i := lo; i < hi; i++ {
+			cells = append(cells, lo*n+i)
+		}
+		for j := lo; j < hi; j++ {
+			cells = append(cells, j*n+hi)
+		}
+		for i := hi; i > lo; i-- {
+			cells = append(cells, hi*n+i)
+		}
+		for j := hi; j > lo; j-- {
+			cells = append(cells, j*n+lo)
+		}
+	}
+	return cells
+}
+
+// Plot implements the plot.Plotter interface.
+func (s *Streamlines) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	for _, line := range s.Lines {
+		pts := make([]vg.Point, len(line))
+		for i, p := range line {
+			pts[i] = vg.Point{X: trX(p.X), Y: trY(p.Y)}
+		}
+		c.StrokeLines(s.LineStyle, c.ClipLinesXY(pts)...)
+
+		if s.ArrowSize == 0 || len(pts) < 2 {
+			continue
+		}
+		// Place the arrow head half way along the line.
+		var total vg.Length
+		for i := 1; i < len(pts); i++ {
+			total += distance(pts[i-1], pts[i])
+		}
+		var length vg.Length
+		for i := 1; i < len(pts); i++ {
+			length += distance(pts[i-1], pts[i])
+			if length >= total/2 {
+				fillArrowHead(&c, s.LineStyle.Color, pts[i-1], pts[i], s.ArrowSize)
+				break
+			}
+		}
+	}
+}
+
+// distance returns the distance between a and b.
+func distance(a, b vg.Point) vg.Length {
+	d := b.Sub(a)
+	return vg.Length(math.Hypot(float64(d.X), float64(d.Y)))
+}
+
+// DataRange implements the plot.DataRanger interface,
+// returning the extent of the vector field.
+func (s *Streamlines) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return s.xmin, s.xmax, s.ymin, s.ymax
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (s *Streamlines) Thumbnail(c *draw.Canvas) {
+	y := c.Center().Y
+	c.StrokeLine2(s.LineStyle, c.Min.X, y, c.Max.X, y)
+	fillArrowHead(c, s.LineStyle.Color, vg.Point{X: c.Min.X, Y: y}, c.Center(), s.ArrowSize)
+}
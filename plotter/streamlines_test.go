@@ -0,0 +1,69 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+)
+
+// ExampleStreamlines draws the streamlines of a vortex
+// in a uniform flow.
+func ExampleStreamlines() {
+	s, err := NewStreamlines(vortex{n: 31, min: -3, max: 3}, 0.8)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Streamlines"
+	p.Add(s)
+
+	err = p.Save(250, 250, "testdata/streamlines.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestStreamlines(t *testing.T) {
+	checkPlot(ExampleStreamlines, t, "streamlines.png")
+}
+
+func TestSpiral(t *testing.T) {
+	want := []int{0, 1, 2, 5, 8, 7, 6, 3, 4}
+	if got := spiral(3); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected spiral order: got:%v want:%v", got, want)
+	}
+	if got := spiral(4); len(got) != 16 {
+		t.Errorf("unexpected number of cells: got:%d want:16", len(got))
+	}
+}
+
+func TestVectorFieldAt(t *testing.T) {
+	f, err := newVectorField(vortex{n: 7, min: -3, max: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	g := vortex{n: 7, min: -3, max: 3}
+	u0, v0 := g.Vector(2, 3)
+	u1, v1 := g.Vector(3, 3)
+	u, v, ok := f.at(-0.5, 0)
+	if !ok {
+		t.Fatal("unexpected location outside field")
+	}
+	if wu, wv := (u0+u1)/2, (v0+v1)/2; math.Abs(u-wu) > 1e-12 || math.Abs(v-wv) > 1e-12 {
+		t.Errorf("unexpected interpolated vector: got:(%v,%v) want:(%v,%v)", u, v, wu, wv)
+	}
+	if _, _, ok := f.at(3.5, 0); ok {
+		t.Error("expected location outside field")
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Swarm implements the Plotter interface, drawing the values at
+// a location as glyphs offset sideways so that they do not overlap,
+// forming a beeswarm, or, if Jitter is not zero, offset randomly to
+// form a strip. It can be drawn over a BoxPlot or QuartPlot at the
+// same location.
+type Swarm struct {
+	// Values holds the values to draw.
+	Values
+
+	// Location is the location of the swarm, on
+	// the X axis or on the Y axis if Horizontal.
+	Location float64
+
+	// Offset is added to the location of the swarm.
+	Offset vg.Length
+
+	// GlyphStyle is the style of the glyphs.
+	draw.GlyphStyle
+
+	// Jitter, if not zero, is the width of the strip
+	// across which the glyphs are spread at random
+	// offsets drawn from a source with the given Seed,
+	// instead of being placed without overlap.
+	Jitter vg.Length
+	Seed   int64
+
+	// Horizontal dictates whether the values are
+	// drawn along the X axis rather than the Y axis.
+	Horizontal bool
+}
+
+// NewSwarm returns a Swarm of the values at
+// the given location.
+func NewSwarm(loc float64, values Valuer) (*Swarm, error) {
+	vs, err := CopyValues(values)
+	if err != nil {
+		return nil, err
+	}
+	return &Swarm{
+		Values:     vs,
+		Location:   loc,
+		GlyphStyle: DefaultGlyphStyle,
+	}, nil
+}
+
+// offsets returns the offsets across the swarm of glyphs at
+// the positions along it. In a strip, the offsets are random.
+// In a swarm, each glyph in order of position is given the
+// offset closest to zero at which it does not overlap the glyphs
+// already placed, which are at least 2*Radius apart.
+func (s *Swarm) offsets(pos []vg.Length) []vg.Length {
+	offs := make([]vg.Length, len(pos))
+	if s.Jitter != 0 {
+		rnd := rand.New(rand.NewSource(s.Seed))
+		for i := range offs {
+			offs[i] = vg.Length(rnd.Float64()-0.5) * s.Jitter
+		}
+		return offs
+	}
+	return swarmOffsets(pos, 2*s.Radius)
+}
+
+// swarmOffsets returns the offsets of glyphs at the positions
+// along a swarm such that no two glyph centers are closer than d.
+func swarmOffsets(pos []vg.Length, d vg.Length) []vg.Length {
+	offs := make([]vg.Length, len(pos))
+	order := make([]int, len(pos))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Stable(byKey{order, func(i int) float64 { return float64(pos[i]) }})
+
+	// A placed glyph forbids the offsets within
+	// an interval around its own.
+	type interval struct{ lo, hi vg.Length }
+	var placed []int
+	for _, i := range order {
+		var forbidden []interval
+		cands := []vg.Length{0}
+		for _, j := range placed {
+			dp := pos[i] - pos[j]
+			if dp >= d || dp <= -d {
+				continue
+			}
+			w := vg.Length(math.Sqrt(float64(d*d - dp*dp)))
+			forbidden = append(forbidden, interval{offs[j] - w, offs[j] + w})
+			cands = append(cands, offs[j]-w, offs[j]+w)
+		}
+		// Choose the candidate closest to zero, preferring
+		// negative offsets, that is not forbidden.
+		best := vg.Length(math.Inf(1))
+		for _, o := range cands {
+			if abs(o) > abs(best) || (abs(o) == abs(best) && o > best) {
+				continue
+			}
+			ok := true
+			for _, f := range forbidden {
+				// Allow a small tolerance so that touching
+				// glyphs are not considered overlapping.
+				if o > f.lo+1e-9 && o < f.hi-1e-9 {
+					ok = false
+					break
+				}
+			}
+			if ok {
+				best = o
+			}
+		}
+		offs[i] = best
+		placed = append(placed, i)
+	}
+	return offs
+}
+
+// abs returns the absolute value of l.
+func abs(l vg.Length) vg.Length {
+	if l < 0 {
+		return -l
+	}
+	return l
+}
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (s *Swarm) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	trLoc, trVal := trX, trY
+	if s.Horizontal {
+		trLoc, trVal = trY, trX
+	}
+	loc := trLoc(s.Location) + s.Offset
+	pos := make([]vg.Length, len(s.Values))
+	for i, v := range s.Values {
+		pos[i] = trVal(v)
+	}
+	for i, o := range s.offsets(pos) {
+		pt := vg.Point{X: loc + o, Y: pos[i]}
+		if s.Horizontal {
+			pt.X, pt.Y = pt.Y, pt.X
+		}
+		if c.Contains(pt) {
+			c.DrawGlyph(s.GlyphStyle, pt)
+		}
+	}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (s *Swarm) DataRange() (xmin, xmax, ymin, ymax float64) {
+	min, max := Range(s.Values)
+	if s.Horizontal {
+		return min, max, s.Location, s.Location
+	}
+	return s.Location, s.Location, min, max
+}
+
+// GlyphBoxes implements the GlyphBoxes method
+// of the plot.GlyphBoxer interface. The boxes
+// are at the location of the swarm, since the
+// offsets depend on the canvas.
+func (s *Swarm) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	bs := make([]plot.GlyphBox, len(s.Values))
+	for i, v := range s.Values {
+		x, y := s.Location, v
+		if s.Horizontal {
+			x, y = y, x
+		}
+		bs[i].X = plt.X.Norm(x)
+		bs[i].Y = plt.Y.Norm(y)
+		bs[i].Rectangle = s.GlyphStyle.Rectangle()
+	}
+	return bs
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (s *Swarm) Thumbnail(c *draw.Canvas) {
+	c.DrawGlyph(s.GlyphStyle, c.Center())
+}
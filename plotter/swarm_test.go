@@ -0,0 +1,90 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// ExampleSwarm draws beeswarms over box plots,
+// and a strip of jittered points.
+func ExampleSwarm() {
+	rnd := rand.New(rand.NewSource(1))
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Swarm and strip plots"
+	p.Y.Label.Text = "Value"
+
+	for i := 0; i < 4; i++ {
+		vs := make(Values, 40)
+		for j := range vs {
+			vs[j] = float64(i) + (1+0.3*float64(i))*rnd.NormFloat64()
+		}
+		b, err := NewBoxPlot(vg.Points(30), float64(i), vs)
+		if err != nil {
+			log.Panic(err)
+		}
+		s, err := NewSwarm(float64(i), vs)
+		if err != nil {
+			log.Panic(err)
+		}
+		s.Radius = vg.Points(1.5)
+		if i == 3 {
+			s.Jitter = vg.Points(24)
+			s.Seed = 1
+		}
+		p.Add(b, s)
+	}
+	p.NominalX("A", "B", "C", "D (strip)")
+
+	err = p.Save(250, 200, "testdata/swarm.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestSwarm(t *testing.T) {
+	checkPlot(ExampleSwarm, t, "swarm.png")
+}
+
+func TestSwarmOffsets(t *testing.T) {
+	const d = 2
+	pos := []vg.Length{0, 0.5, 1, 0.2, 10, 0.1, 3}
+	offs := swarmOffsets(pos, d)
+	for i := range pos {
+		for j := i + 1; j < len(pos); j++ {
+			dist := math.Hypot(float64(pos[i]-pos[j]), float64(offs[i]-offs[j]))
+			if dist < d-1e-6 {
+				t.Errorf("glyphs %d and %d overlap: distance %v", i, j, dist)
+			}
+		}
+	}
+	// The first glyph and the isolated
+	// glyphs are not offset.
+	for _, i := range []int{0, 4} {
+		if offs[i] != 0 {
+			t.Errorf("unexpected offset of glyph %d: got:%v want:0", i, offs[i])
+		}
+	}
+
+	s := &Swarm{Jitter: 4, Seed: 1}
+	first := s.offsets(pos)
+	for i, o := range s.offsets(pos) {
+		if o != first[i] {
+			t.Errorf("jitter of glyph %d not reproducible", i)
+		}
+		if o < -2 || o > 2 {
+			t.Errorf("jitter of glyph %d outside strip: %v", i, o)
+		}
+	}
+}
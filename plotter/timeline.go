@@ -0,0 +1,355 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// Interval is a single interval of a Timeline.
+type Interval struct {
+	// Start and End are the beginning and end of
+	// the interval along the X axis.
+	Start, End float64
+
+	// Lane is the name of the lane holding the interval.
+	Lane string
+
+	// Label is drawn inside the bar of the interval
+	// if it fits.
+	Label string
+
+	// Color is the fill color of the bar. If Color
+	// is nil the Color of the Timeline is used.
+	Color color.Color
+}
+
+// Milestone is a single point in time marked on a Timeline.
+type Milestone struct {
+	// At is the location of the milestone along the X axis.
+	At float64
+
+	// Lane is the name of the lane holding the milestone.
+	Lane string
+
+	// Label is drawn to the right of the milestone.
+	Label string
+}
+
+// Dependency is an arrow drawn from the end of one interval
+// of a Timeline to the start of another. From and To are
+// indices into the Intervals of the Timeline.
+type Dependency struct {
+	From, To int
+}
+
+// Timeline implements the Plotter interface, drawing
+// intervals as horizontal bars in named lanes, as in a
+// Gantt chart. Overlapping intervals in a lane are packed
+// into sub-rows sharing the height of the lane.
+//
+// Lanes are placed at integer Y locations with the first
+// lane at the top, so the lanes can be labelled by passing
+// the result of LaneNames to the NominalY method of the plot.
+type Timeline struct {
+	// Intervals are the intervals drawn as bars.
+	Intervals []Interval
+
+	// Milestones are drawn as glyphs at the
+	// center of their lanes.
+	Milestones []Milestone
+
+	// Dependencies are drawn as arrows between
+	// intervals. Dependencies with indices out
+	// of range are not drawn.
+	Dependencies []Dependency
+
+	// Lanes holds the names of the lanes from top to
+	// bottom. Intervals and milestones in lanes not
+	// listed in Lanes are not drawn.
+	Lanes []string
+
+	// BarHeight is the height of the bars of a lane
+	// as a fraction of the distance between lanes.
+	BarHeight float64
+
+	// Color is the default fill color of the bars.
+	Color color.Color
+
+	// LineStyle is the style of the outline of the bars.
+	// No outline is drawn if the line width is zero.
+	LineStyle draw.LineStyle
+
+	// TextStyle is the style of the interval
+	// and milestone labels.
+	TextStyle draw.TextStyle
+
+	// MilestoneStyle is the style of the milestone glyphs.
+	MilestoneStyle draw.GlyphStyle
+
+	// ArrowStyle is the style of the dependency arrows.
+	ArrowStyle draw.LineStyle
+
+	// ArrowSize is the length of the dependency arrow heads.
+	ArrowSize vg.Length
+}
+
+// NewTimeline returns a new Timeline drawing the given
+// intervals using the DefaultFont and DefaultFontSize for
+// labels. Lanes are ordered by their first appearance in ivs.
+func NewTimeline(ivs []Interval) (*Timeline, error) {
+	if len(ivs) == 0 {
+		return nil, ErrNoData
+	}
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	t := &Timeline{
+		Intervals: make([]Interval, len(ivs)),
+		BarHeight: 0.8,
+		Color:     color.Gray{196},
+		LineStyle: DefaultLineStyle,
+		TextStyle: draw.TextStyle{
+			Color:  color.Black,
+			Font:   fnt,
+			XAlign: draw.XCenter,
+			YAlign: draw.YCenter,
+		},
+		MilestoneStyle: draw.GlyphStyle{
+			Color:  color.Black,
+			Radius: vg.Points(4),
+			Shape:  draw.DiamondGlyph{},
+		},
+		ArrowStyle: DefaultLineStyle,
+		ArrowSize:  vg.Points(5),
+	}
+	seen := make(map[string]bool)
+	for i, iv := range ivs {
+		if err := CheckFloats(iv.Start, iv.End); err != nil {
+			return nil, err
+		}
+		if iv.End < iv.Start {
+			return nil, errors.New("Interval ends before it starts")
+		}
+		t.Intervals[i] = iv
+		if !seen[iv.Lane] {
+			seen[iv.Lane] = true
+			t.Lanes = append(t.Lanes, iv.Lane)
+		}
+	}
+	return t, nil
+}
+
+// LaneNames returns the names of the lanes from bottom
+// to top, the order expected by the NominalY method of
+// plot.Plot.
+func (t *Timeline) LaneNames() []string {
+	names := make([]string, len(t.Lanes))
+	for i, l := range t.Lanes {
+		names[len(names)-1-i] = l
+	}
+	return names
+}
+
+// laneY returns the Y location of the center of the named
+// lane and whether the lane exists.
+func (t *Timeline) laneY(lane string) (float64, bool) {
+	for i, l := range t.Lanes {
+		if l == lane {
+			return float64(len(t.Lanes) - 1 - i), true
+		}
+	}
+	return 0, false
+}
+
+// pack assigns each interval to a sub-row of its lane so
+// that no two intervals in a sub-row overlap. It returns
+// the sub-row of each interval, counting from the top of
+// the lane, and the number of sub-rows in each lane.
+func (t *Timeline) pack() (row []int, rows map[string]int) {
+	byLane := make(map[string][]int)
+	for i, iv := range t.Intervals {
+		byLane[iv.Lane] = append(byLane[iv.Lane], i)
+	}
+
+	row = make([]int, len(t.Intervals))
+	rows = make(map[string]int)
+	for lane, idx := range byLane {
+		sort.Sort(byStart{idx, t.Intervals})
+		var ends []float64
+		for _, i := range idx {
+			iv := t.Intervals[i]
+			r := 0
+			for r < len(ends) && ends[r] > iv.Start {
+				r++
+			}
+			if r == len(ends) {
+				ends = append(ends, iv.End)
+			} else {
+				ends[r] = iv.End
+			}
+			row[i] = r
+		}
+		rows[lane] = len(ends)
+	}
+	return row, rows
+}
+
+// byStart sorts indices into a slice of intervals by the
+// start of the intervals, breaking ties by their index.
+type byStart struct {
+	idx []int
+	ivs []Interval
+}
+
+func (b byStart) Len() int { return len(b.idx) }
+func (b byStart) Less(i, j int) bool {
+	si, sj := b.ivs[b.idx[i]].Start, b.ivs[b.idx[j]].Start
+	if si != sj {
+		return si < sj
+	}
+	return b.idx[i] < b.idx[j]
+}
+func (b byStart) Swap(i, j int) { b.idx[i], b.idx[j] = b.idx[j], b.idx[i] }
+
+// barExtent returns the bottom and top Y locations of the
+// bar of the ith interval, given the result of pack.
+func (t *Timeline) barExtent(i int, row []int, rows map[string]int) (bottom, top float64, ok bool) {
+	y, ok := t.laneY(t.Intervals[i].Lane)
+	if !ok {
+		return 0, 0, false
+	}
+	h := t.BarHeight / float64(rows[t.Intervals[i].Lane])
+	top = y + t.BarHeight/2 - float64(row[i])*h
+	return top - h, top, true
+}
+
+// Plot implements the plot.Plotter interface.
+func (t *Timeline) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	row, rows := t.pack()
+
+	for i, iv := range t.Intervals {
+		bottom, top, ok := t.barExtent(i, row, rows)
+		if !ok {
+			continue
+		}
+		xmin, xmax := trX(iv.Start), trX(iv.End)
+		ymin, ymax := trY(bottom), trY(top)
+		pts := []vg.Point{{xmin, ymin}, {xmax, ymin}, {xmax, ymax}, {xmin, ymax}}
+		col := iv.Color
+		if col == nil {
+			col = t.Color
+		}
+		c.FillPolygon(col, c.ClipPolygonXY(pts))
+		if t.LineStyle.Width != 0 {
+			c.StrokeLines(t.LineStyle, c.ClipLinesXY(append(pts, pts[0]))...)
+		}
+
+		if iv.Label == "" {
+			continue
+		}
+		mid := vg.Point{X: (xmin + xmax) / 2, Y: (ymin + ymax) / 2}
+		if t.TextStyle.Width(iv.Label) > xmax-xmin || t.TextStyle.Height(iv.Label) > ymax-ymin || !c.Contains(mid) {
+			continue
+		}
+		c.FillText(t.TextStyle, mid, iv.Label)
+	}
+
+	for _, d := range t.Dependencies {
+		if d.From < 0 || d.From >= len(t.Intervals) || d.To < 0 || d.To >= len(t.Intervals) {
+			continue
+		}
+		fb, ft, ok := t.barExtent(d.From, row, rows)
+		if !ok {
+			continue
+		}
+		tb, tt, ok := t.barExtent(d.To, row, rows)
+		if !ok {
+			continue
+		}
+		from := vg.Point{X: trX(t.Intervals[d.From].End), Y: trY((fb + ft) / 2)}
+		to := vg.Point{X: trX(t.Intervals[d.To].Start), Y: trY((tb + tt) / 2)}
+
+		// Route the arrow through a vertical segment midway
+		// between the intervals when there is room to do so.
+		line := []vg.Point{from, to}
+		if to.X > from.X && to.Y != from.Y {
+			mx := (from.X + to.X) / 2
+			line = []vg.Point{from, {mx, from.Y}, {mx, to.Y}, to}
+		}
+		c.StrokeLines(t.ArrowStyle, c.ClipLinesXY(line)...)
+		fillArrowHead(&c, t.ArrowStyle.Color, line[len(line)-2], to, t.ArrowSize)
+	}
+
+	lsty := t.TextStyle
+	lsty.XAlign = draw.XLeft
+	for _, m := range t.Milestones {
+		y, ok := t.laneY(m.Lane)
+		if !ok {
+			continue
+		}
+		pt := vg.Point{X: trX(m.At), Y: trY(y)}
+		c.DrawGlyph(t.MilestoneStyle, pt)
+		if m.Label != "" && c.Contains(pt) {
+			pt.X += t.MilestoneStyle.Radius + vg.Points(2)
+			c.FillText(lsty, pt, m.Label)
+		}
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (t *Timeline) DataRange() (xmin, xmax, ymin, ymax float64) {
+	xmin, xmax = math.Inf(1), math.Inf(-1)
+	for _, iv := range t.Intervals {
+		xmin = math.Min(xmin, iv.Start)
+		xmax = math.Max(xmax, iv.End)
+	}
+	for _, m := range t.Milestones {
+		xmin = math.Min(xmin, m.At)
+		xmax = math.Max(xmax, m.At)
+	}
+	return xmin, xmax, -0.5, float64(len(t.Lanes)) - 0.5
+}
+
+// GlyphBoxes implements the plot.GlyphBoxer interface,
+// making room for the milestone glyphs.
+func (t *Timeline) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	var boxes []plot.GlyphBox
+	for _, m := range t.Milestones {
+		y, ok := t.laneY(m.Lane)
+		if !ok {
+			continue
+		}
+		boxes = append(boxes, plot.GlyphBox{
+			X:         plt.X.Norm(m.At),
+			Y:         plt.Y.Norm(y),
+			Rectangle: t.MilestoneStyle.Rectangle(),
+		})
+	}
+	return boxes
+}
+
+// Thumbnail implements the plot.Thumbnailer interface.
+func (t *Timeline) Thumbnail(c *draw.Canvas) {
+	pts := []vg.Point{
+		{c.Min.X, c.Min.Y},
+		{c.Max.X, c.Min.Y},
+		{c.Max.X, c.Max.Y},
+		{c.Min.X, c.Max.Y},
+	}
+	c.FillPolygon(t.Color, c.ClipPolygonXY(pts))
+	if t.LineStyle.Width != 0 {
+		c.StrokeLines(t.LineStyle, c.ClipLinesXY(append(pts, pts[0]))...)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"image/color"
+	"log"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg/draw"
+	"github.com/gonum/plot/vg/vgimg"
+)
+
+// ExampleTimeline draws a job schedule with overlapping
+// jobs, a milestone and dependencies between jobs.
+func ExampleTimeline() {
+	blue := color.RGBA{R: 120, G: 160, B: 220, A: 255}
+	orange := color.RGBA{R: 240, G: 170, B: 90, A: 255}
+
+	tl, err := NewTimeline([]Interval{
+		{Start: 0, End: 3, Lane: "fetch", Label: "fetch", Color: blue},
+		{Start: 2, End: 6, Lane: "build", Label: "compile", Color: blue},
+		{Start: 4, End: 8, Lane: "build", Label: "link", Color: blue},
+		{Start: 6, End: 9, Lane: "build", Label: "docs"},
+		{Start: 8, End: 11, Lane: "test", Label: "unit", Color: orange},
+		{Start: 9, End: 12, Lane: "test", Label: "e2e", Color: orange},
+		{Start: 12, End: 14, Lane: "deploy", Label: "ship"},
+	})
+	if err != nil {
+		log.Panic(err)
+	}
+	tl.Dependencies = []Dependency{{0, 1}, {2, 4}, {5, 6}}
+	tl.Milestones = []Milestone{{At: 14, Lane: "deploy", Label: "release"}}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Timeline"
+	p.X.Label.Text = "Hour"
+	p.Add(tl)
+	p.NominalY(tl.LaneNames()...)
+	p.X.Max = 17
+
+	err = p.Save(300, 200, "testdata/timeline.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	checkPlot(ExampleTimeline, t, "timeline.png")
+}
+
+func TestTimelinePack(t *testing.T) {
+	tl, err := NewTimeline([]Interval{
+		{Start: 0, End: 4, Lane: "a"},
+		{Start: 1, End: 2, Lane: "a"},
+		{Start: 2, End: 3, Lane: "a"},
+		{Start: 3, End: 5, Lane: "a"},
+		{Start: 4, End: 6, Lane: "a"},
+		{Start: 0, End: 1, Lane: "b"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	row, rows := tl.pack()
+	if want := []int{0, 1, 1, 1, 0, 0}; !reflect.DeepEqual(row, want) {
+		t.Errorf("unexpected sub-rows: got:%v want:%v", row, want)
+	}
+	if want := map[string]int{"a": 2, "b": 1}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("unexpected sub-row counts: got:%v want:%v", rows, want)
+	}
+	if got, want := tl.LaneNames(), []string{"b", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected lane names: got:%v want:%v", got, want)
+	}
+
+	_, err = NewTimeline([]Interval{{Start: 2, End: 1}})
+	if err == nil {
+		t.Error("expected error for reversed interval")
+	}
+}
+
+func TestTimelineInvalidDependency(t *testing.T) {
+	tl, err := NewTimeline([]Interval{
+		{Start: 0, End: 1, Lane: "a"},
+		{Start: 2, End: 3, Lane: "a"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tl.Dependencies = []Dependency{{From: 0, To: 1}, {From: 1, To: 2}, {From: -1, To: 0}}
+
+	p, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Add(tl)
+	p.Draw(draw.New(vgimg.New(100, 100)))
+}
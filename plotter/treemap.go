@@ -0,0 +1,361 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// TreeNode is a node of a weighted tree.
+type TreeNode struct {
+	// Label is the label of the node.
+	Label string
+
+	// Weight is the weight of a leaf node.
+	// The weight of a node with children is
+	// the sum of the weights of its children.
+	Weight float64
+
+	// Children holds the children of the node.
+	Children []*TreeNode
+}
+
+// TreemapLayout specifies the algorithm used
+// to lay out the children of a Treemap node.
+type TreemapLayout int
+
+const (
+	// Squarified lays out the children in rows
+	// chosen to keep the aspect ratios of their
+	// rectangles close to one, using the algorithm
+	// of Bruls, Huizing and van Wijk.
+	Squarified TreemapLayout = iota
+
+	// SliceAndDice lays out the children side by
+	// side, alternating between horizontal and
+	// vertical division at each level.
+	SliceAndDice
+)
+
+// Treemap implements the Plotter interface, drawing a tree of
+// weights as nested rectangles with areas proportional to the
+// weights. The root of the tree fills the unit square, which is
+// laid out in canvas coordinates so that the rectangles follow
+// the aspect of the canvas, and the axes of the plot are hidden
+// when the Treemap is added to it.
+type Treemap struct {
+	// Root is the root of the tree. The root
+	// itself is not drawn.
+	Root *TreeNode
+
+	// Layout is the layout algorithm.
+	Layout TreemapLayout
+
+	// Padding is the space between the rectangle
+	// of a node and the rectangles of its children.
+	Padding vg.Length
+
+	// Palette, if not nil, is used to color the
+	// children of the root, which are given the
+	// colors of the palette in turn, and their
+	// descendants. Otherwise, each node is colored
+	// gray by its depth.
+	Palette palette.Palette
+
+	// LineStyle is the style of the outline
+	// of each rectangle.
+	LineStyle draw.LineStyle
+
+	// TextStyle is the style of the labels. Labels
+	// of leaves are centered in their rectangles and
+	// labels of other nodes are drawn at the top left
+	// of theirs. Labels that do not fit are not drawn.
+	TextStyle draw.TextStyle
+
+	// weights holds the weight of each node.
+	weights map[*TreeNode]float64
+}
+
+// NewTreemap returns a Treemap of the tree with the given root.
+// The weights of the leaves must be non-negative and their sum
+// must be positive.
+func NewTreemap(root *TreeNode) (*Treemap, error) {
+	if root == nil {
+		return nil, ErrNoData
+	}
+	fnt, err := vg.MakeFont(DefaultFont, DefaultFontSize)
+	if err != nil {
+		return nil, err
+	}
+	t := &Treemap{
+		Root:      root,
+		Padding:   vg.Points(2),
+		LineStyle: draw.LineStyle{Color: color.White, Width: vg.Points(1)},
+		TextStyle: draw.TextStyle{Font: fnt},
+		weights:   make(map[*TreeNode]float64),
+	}
+	w, err := t.weigh(root)
+	if err != nil {
+		return nil, err
+	}
+	if w == 0 {
+		return nil, errors.New("Treemap has zero total weight")
+	}
+	return t, nil
+}
+
+// weigh records the weights of n and its descendants,
+// returning the weight of n.
+func (t *Treemap) weigh(n *TreeNode) (float64, error) {
+	if len(n.Children) == 0 {
+		if err := CheckFloats(n.Weight); err != nil {
+			return 0, err
+		}
+		if n.Weight < 0 {
+			return 0, errors.New("Negative Treemap weight")
+		}
+		t.weights[n] = n.Weight
+		return n.Weight, nil
+	}
+	var sum float64
+	for _, ch := range n.Children {
+		w, err := t.weigh(ch)
+		if err != nil {
+			return 0, err
+		}
+		sum += w
+	}
+	t.weights[n] = sum
+	return sum, nil
+}
+
+// HidesAxes implements the plot.AxesHider interface.
+func (t *Treemap) HidesAxes() bool { return true }
+
+// Plot implements the Plot method of the plot.Plotter interface.
+func (t *Treemap) Plot(c draw.Canvas, plt *plot.Plot) {
+	trX, trY := plt.Transforms(&c)
+	r := vg.Rectangle{
+		Min: vg.Point{X: trX(0), Y: trY(0)},
+		Max: vg.Point{X: trX(1), Y: trY(1)},
+	}
+	var pal []color.Color
+	if t.Palette != nil {
+		pal = t.Palette.Colors()
+	}
+	t.drawChildren(&c, t.Root, r, 0, pal, nil)
+}
+
+// drawChildren draws the children of n, which are at the given
+// depth, within the rectangle r. The children are given the
+// colors of pal in turn if it is not empty, or else clr if it is
+// not nil, or else a gray for their depth.
+func (t *Treemap) drawChildren(c *draw.Canvas, n *TreeNode, r vg.Rectangle, depth int, pal []color.Color, clr color.Color) {
+	ws := make([]float64, len(n.Children))
+	for i, ch := range n.Children {
+		ws[i] = t.weights[ch]
+	}
+	var rects []vg.Rectangle
+	switch t.Layout {
+	case Squarified:
+		rects = squarify(ws, r)
+	case SliceAndDice:
+		rects = slice(ws, r, depth%2 == 0)
+	default:
+		panic("plotter: unknown treemap layout")
+	}
+
+	for i, ch := range n.Children {
+		rect := rects[i]
+		if ws[i] == 0 || rect.Size().X <= 0 || rect.Size().Y <= 0 {
+			continue
+		}
+		fill := clr
+		switch {
+		case len(pal) != 0:
+			fill = pal[i%len(pal)]
+		case fill == nil:
+			fill = depthGray(depth)
+		}
+		pts := []vg.Point{rect.Min, {X: rect.Max.X, Y: rect.Min.Y}, rect.Max, {X: rect.Min.X, Y: rect.Max.Y}}
+		c.FillPolygon(fill, pts)
+		c.StrokeLines(t.LineStyle, append(pts, pts[0]))
+
+		if len(ch.Children) == 0 {
+			t.drawLabel(c, ch.Label, rect, draw.XCenter, draw.YCenter,
+				vg.Point{X: (rect.Min.X + rect.Max.X) / 2, Y: (rect.Min.Y + rect.Max.Y) / 2})
+			continue
+		}
+
+		inner := vg.Rectangle{
+			Min: vg.Point{X: rect.Min.X + t.Padding, Y: rect.Min.Y + t.Padding},
+			Max: vg.Point{X: rect.Max.X - t.Padding, Y: rect.Max.Y - t.Padding},
+		}
+		if t.drawLabel(c, ch.Label, inner, draw.XLeft, draw.YTop, vg.Point{X: inner.Min.X, Y: inner.Max.Y}) {
+			inner.Max.Y -= t.TextStyle.Height(ch.Label)
+		}
+		var childColor color.Color
+		if clr != nil || len(pal) != 0 {
+			childColor = fill
+		}
+		t.drawChildren(c, ch, inner, depth+1, nil, childColor)
+	}
+}
+
+// drawLabel draws the label at pt with the given alignment if
+// it fits within r, returning whether the label was drawn.
+func (t *Treemap) drawLabel(c *draw.Canvas, label string, r vg.Rectangle, x draw.XAlignment, y draw.YAlignment, pt vg.Point) bool {
+	if label == "" {
+		return false
+	}
+	size := r.Size()
+	w, h := t.TextStyle.Width(label), t.TextStyle.Height(label)
+	if w > size.X || h > size.Y || (x == draw.XLeft && 2*h > size.Y) {
+		return false
+	}
+	sty := t.TextStyle
+	sty.XAlign = x
+	sty.YAlign = y
+	c.FillText(sty, pt, label)
+	return true
+}
+
+// depthGray returns the fill color of nodes
+// at the given depth when there is no palette.
+func depthGray(depth int) color.Color {
+	return color.Gray{Y: uint8(140 + 25*(depth%5))}
+}
+
+// DataRange implements the DataRange method
+// of the plot.DataRanger interface.
+func (t *Treemap) DataRange() (xmin, xmax, ymin, ymax float64) {
+	return 0, 1, 0, 1
+}
+
+// squarify returns the rectangles within r of items with the
+// weights ws, laid out with the squarified algorithm. The items
+// are placed in decreasing order of weight, in rows along the
+// shorter side of the remaining space.
+func squarify(ws []float64, r vg.Rectangle) []vg.Rectangle {
+	rects := make([]vg.Rectangle, len(ws))
+	order := make([]int, len(ws))
+	var total float64
+	for i, w := range ws {
+		order[i] = i
+		total += w
+	}
+	if total == 0 {
+		return rects
+	}
+	sort.Stable(sort.Reverse(byKey{order, func(i int) float64 { return ws[i] }}))
+
+	x0, y0 := float64(r.Min.X), float64(r.Min.Y)
+	x1, y1 := float64(r.Max.X), float64(r.Max.Y)
+	scale := (x1 - x0) * (y1 - y0) / total
+	area := func(i int) float64 { return ws[order[i]] * scale }
+
+	for start := 0; start < len(order); {
+		side := math.Min(x1-x0, y1-y0)
+		end := start + 1
+		sum := area(start)
+		for end < len(order) {
+			next := sum + area(end)
+			if worst(area(start), area(end), next, side) > worst(area(start), area(end-1), sum, side) {
+				break
+			}
+			sum = next
+			end++
+		}
+
+		if sum == 0 {
+			for i := start; i < len(order); i++ {
+				rects[order[i]] = vg.Rectangle{
+					Min: vg.Point{X: vg.Length(x0), Y: vg.Length(y0)},
+					Max: vg.Point{X: vg.Length(x0), Y: vg.Length(y0)},
+				}
+			}
+			break
+		}
+		thick := sum / side
+		if x1-x0 >= y1-y0 {
+			// Lay the row out as a column at the left,
+			// from the top down.
+			y := y1
+			for i := start; i < end; i++ {
+				h := area(i) / thick
+				rects[order[i]] = vg.Rectangle{
+					Min: vg.Point{X: vg.Length(x0), Y: vg.Length(y - h)},
+					Max: vg.Point{X: vg.Length(x0 + thick), Y: vg.Length(y)},
+				}
+				y -= h
+			}
+			x0 += thick
+		} else {
+			// Lay the row out along the top,
+			// from left to right.
+			x := x0
+			for i := start; i < end; i++ {
+				w := area(i) / thick
+				rects[order[i]] = vg.Rectangle{
+					Min: vg.Point{X: vg.Length(x), Y: vg.Length(y1 - thick)},
+					Max: vg.Point{X: vg.Length(x + w), Y: vg.Length(y1)},
+				}
+				x += w
+			}
+			y1 -= thick
+		}
+		start = end
+	}
+	return rects
+}
+
+// worst returns the greatest aspect ratio of the rectangles
+// of a row with the given largest and smallest areas and
+// total area laid out along a side of the given length.
+func worst(largest, smallest, sum, side float64) float64 {
+	s2, w2 := sum*sum, side*side
+	return math.Max(w2*largest/s2, s2/(w2*smallest))
+}
+
+// slice returns the rectangles within r of items with the
+// weights ws, laid out side by side from the left if
+// horizontal is true, and otherwise from the top down.
+func slice(ws []float64, r vg.Rectangle, horizontal bool) []vg.Rectangle {
+	rects := make([]vg.Rectangle, len(ws))
+	var total float64
+	for _, w := range ws {
+		total += w
+	}
+	if total == 0 {
+		return rects
+	}
+	size := r.Size()
+	x, y := r.Min.X, r.Max.Y
+	for i, w := range ws {
+		f := vg.Length(w / total)
+		if horizontal {
+			rects[i] = vg.Rectangle{
+				Min: vg.Point{X: x, Y: r.Min.Y},
+				Max: vg.Point{X: x + f*size.X, Y: r.Max.Y},
+			}
+			x += f * size.X
+		} else {
+			rects[i] = vg.Rectangle{
+				Min: vg.Point{X: r.Min.X, Y: y - f*size.Y},
+				Max: vg.Point{X: r.Max.X, Y: y},
+			}
+			y -= f * size.Y
+		}
+	}
+	return rects
+}
@@ -0,0 +1,161 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"math"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/palette"
+	"github.com/gonum/plot/vg"
+)
+
+// ExampleTreemap draws a squarified treemap
+// of the disk usage of a directory tree.
+func ExampleTreemap() {
+	leaf := func(label string, w float64) *TreeNode {
+		return &TreeNode{Label: label, Weight: w}
+	}
+	root := &TreeNode{Children: []*TreeNode{
+		{Label: "usr", Children: []*TreeNode{
+			leaf("lib", 40),
+			leaf("share", 25),
+			leaf("bin", 12),
+			leaf("include", 6),
+			leaf("src", 3),
+		}},
+		{Label: "home", Children: []*TreeNode{
+			leaf("photos", 35),
+			leaf("video", 28),
+			leaf("docs", 8),
+			leaf("mail", 4),
+		}},
+		{Label: "var", Children: []*TreeNode{
+			leaf("log", 10),
+			leaf("cache", 9),
+			leaf("lib", 5),
+		}},
+		{Label: "opt", Children: []*TreeNode{
+			leaf("tools", 7),
+			leaf("sdk", 5),
+		}},
+		leaf("etc", 2),
+	}}
+
+	t, err := NewTreemap(root)
+	if err != nil {
+		log.Panic(err)
+	}
+	t.Palette = palette.Rainbow(5, palette.Red, palette.Blue, 0.4, 0.9, 1)
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Disk usage"
+	p.Add(t)
+
+	err = p.Save(300, 200, "testdata/treemap.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestTreemap(t *testing.T) {
+	checkPlot(ExampleTreemap, t, "treemap.png")
+}
+
+func TestSquarify(t *testing.T) {
+	// The example of Bruls, Huizing and van Wijk.
+	ws := []float64{6, 6, 4, 3, 2, 2, 1}
+	r := vg.Rectangle{Max: vg.Point{X: 6, Y: 4}}
+	rects := squarify(ws, r)
+
+	var total float64
+	for i, rect := range rects {
+		size := rect.Size()
+		if got := float64(size.X * size.Y); math.Abs(got-ws[i]) > 1e-9 {
+			t.Errorf("unexpected area of rectangle %d: got:%v want:%v", i, got, ws[i])
+		}
+		if rect.Min.X < r.Min.X-1e-9 || rect.Min.Y < r.Min.Y-1e-9 || rect.Max.X > r.Max.X+1e-9 || rect.Max.Y > r.Max.Y+1e-9 {
+			t.Errorf("rectangle %d outside bounds: %v", i, rect)
+		}
+		total += float64(size.X * size.Y)
+	}
+	if math.Abs(total-24) > 1e-9 {
+		t.Errorf("unexpected total area: got:%v want:24", total)
+	}
+
+	// The first row holds the two largest
+	// items, stacked at the left.
+	want := []vg.Rectangle{
+		{Min: vg.Point{X: 0, Y: 2}, Max: vg.Point{X: 3, Y: 4}},
+		{Min: vg.Point{X: 0, Y: 0}, Max: vg.Point{X: 3, Y: 2}},
+	}
+	for i, w := range want {
+		if rects[i] != w {
+			t.Errorf("unexpected rectangle %d: got:%v want:%v", i, rects[i], w)
+		}
+	}
+}
+
+func TestSliceAndDice(t *testing.T) {
+	r := vg.Rectangle{Max: vg.Point{X: 4, Y: 2}}
+	for _, test := range []struct {
+		horizontal bool
+		want       []vg.Rectangle
+	}{
+		{
+			horizontal: true,
+			want: []vg.Rectangle{
+				{Min: vg.Point{X: 0, Y: 0}, Max: vg.Point{X: 1, Y: 2}},
+				{Min: vg.Point{X: 1, Y: 0}, Max: vg.Point{X: 4, Y: 2}},
+			},
+		},
+		{
+			horizontal: false,
+			want: []vg.Rectangle{
+				{Min: vg.Point{X: 0, Y: 1.5}, Max: vg.Point{X: 4, Y: 2}},
+				{Min: vg.Point{X: 0, Y: 0}, Max: vg.Point{X: 4, Y: 1.5}},
+			},
+		},
+	} {
+		got := slice([]float64{1, 3}, r, test.horizontal)
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("unexpected rectangle %d for horizontal=%t: got:%v want:%v",
+					i, test.horizontal, got[i], test.want[i])
+			}
+		}
+	}
+}
+
+func TestNewTreemap(t *testing.T) {
+	root := &TreeNode{Children: []*TreeNode{
+		{Weight: 1},
+		{Children: []*TreeNode{{Weight: 2}, {Weight: 3}}},
+	}}
+	tm, err := NewTreemap(root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := tm.weights[root]; got != 6 {
+		t.Errorf("unexpected root weight: got:%v want:6", got)
+	}
+	if got := tm.weights[root.Children[1]]; got != 5 {
+		t.Errorf("unexpected branch weight: got:%v want:5", got)
+	}
+
+	_, err = NewTreemap(&TreeNode{Children: []*TreeNode{{Weight: -1}, {Weight: 2}}})
+	if err == nil {
+		t.Error("expected error for negative weight")
+	}
+	_, err = NewTreemap(&TreeNode{})
+	if err == nil {
+		t.Error("expected error for zero total weight")
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+	"github.com/gonum/plot/vg/draw"
+)
+
+// A Waterfall presents a series of changes to a running
+// total as floating bars, each starting where the previous
+// bar ended.
+type Waterfall struct {
+	// Values holds the change in the running total
+	// represented by each bar.
+	Values
+
+	// Width is the width of the bars.
+	Width vg.Length
+
+	// IncreaseColor and DecreaseColor are the fill colors
+	// of bars that increase and decrease the running total
+	// respectively. TotalColor is the fill color of the
+	// subtotal bars.
+	IncreaseColor, DecreaseColor, TotalColor color.Color
+
+	// LineStyle is the style of the outline of the bars.
+	draw.LineStyle
+
+	// ConnectorStyle is the style of the lines joining
+	// the end of each bar to the start of the next. No
+	// connectors are drawn if the line width is zero.
+	ConnectorStyle draw.LineStyle
+
+	// Subtotals holds the indices of bars that show the
+	// running total from zero rather than a change. The
+	// values at these indices are ignored.
+	Subtotals []int
+
+	// Offset is added to the X location of each bar.
+	// When the Offset is zero, the bars are drawn
+	// centered at their X location.
+	Offset vg.Length
+
+	// XMin is the X location of the first bar.
+	XMin float64
+
+	// Horizontal dictates whether the bars should be in the vertical
+	// (default) or horizontal direction. If Horizontal is true, all
+	// X locations and distances referred to here will actually be Y
+	// locations and distances.
+	Horizontal bool
+}
+
+// NewWaterfall returns a new waterfall chart with a single bar
+// for each of the changes in vs. The x location of each bar
+// corresponds to the index of its value in the Valuer.
+func NewWaterfall(vs Valuer, width vg.Length) (*Waterfall, error) {
+	if width <= 0 {
+		return nil, errors.New("Width parameter was not positive")
+	}
+	values, err := CopyValues(vs)
+	if err != nil {
+		return nil, err
+	}
+	return &Waterfall{
+		Values:         values,
+		Width:          width,
+		IncreaseColor:  color.RGBA{G: 160, A: 255},
+		DecreaseColor:  color.RGBA{R: 196, A: 255},
+		TotalColor:     color.RGBA{B: 196, A: 255},
+		LineStyle:      DefaultLineStyle,
+		ConnectorStyle: draw.LineStyle{Color: color.Gray{128}, Width: vg.Points(0.5)},
+	}, nil
+}
+
+// isSubtotal returns whether the ith bar is a subtotal.
+func (w *Waterfall) isSubtotal(i int) bool {
+	for _, s := range w.Subtotals {
+		if s == i {
+			return true
+		}
+	}
+	return false
+}
+
+// Extent returns the start and end values of the ith bar.
+func (w *Waterfall) Extent(i int) (start, end float64) {
+	var total float64
+	for j := 0; j < i; j++ {
+		if !w.isSubtotal(j) {
+			total += w.Values[j]
+		}
+	}
+	if w.isSubtotal(i) {
+		return 0, total
+	}
+	return total, total + w.Values[i]
+}
+
+// colorOf returns the fill color of the ith bar.
+func (w *Waterfall) colorOf(i int) color.Color {
+	start, end := w.Extent(i)
+	switch {
+	case w.isSubtotal(i):
+		return w.TotalColor
+	case end < start:
+		return w.DecreaseColor
+	default:
+		return w.IncreaseColor
+	}
+}
+
+// Plot implements the plot.Plotter interface.
+func (w *Waterfall) Plot(c draw.Canvas, plt *plot.Plot) {
+	trCat, trVal := plt.Transforms(&c)
+	if w.Horizontal {
+		trCat, trVal = trVal, trCat
+	}
+
+	for i := range w.Values {
+		start, end := w.Extent(i)
+		catMin := trCat(w.XMin+float64(i)) - w.Width/2 + w.Offset
+		catMax := catMin + w.Width
+
+		// Connect this bar to the next one at the running total.
+		if i < len(w.Values)-1 && w.ConnectorStyle.Width != 0 {
+			next := trCat(w.XMin+float64(i+1)) - w.Width/2 + w.Offset
+			val := trVal(end)
+			var lines [][]vg.Point
+			if !w.Horizontal {
+				lines = c.ClipLinesXY([]vg.Point{{catMax, val}, {next, val}})
+			} else {
+				lines = c.ClipLinesXY([]vg.Point{{val, catMax}, {val, next}})
+			}
+			c.StrokeLines(w.ConnectorStyle, lines...)
+		}
+
+		mid := trCat(w.XMin + float64(i))
+		if !w.Horizontal {
+			if !c.ContainsX(mid) {
+				continue
+			}
+		} else {
+			if !c.ContainsY(mid) {
+				continue
+			}
+		}
+		drawBar(c, w.Horizontal, catMin, catMax, trVal(start), trVal(end), w.colorOf(i), w.LineStyle)
+	}
+}
+
+// DataRange implements the plot.DataRanger interface.
+func (w *Waterfall) DataRange() (xmin, xmax, ymin, ymax float64) {
+	catMin := w.XMin
+	catMax := catMin + float64(len(w.Values)-1)
+
+	valMin := math.Inf(1)
+	valMax := math.Inf(-1)
+	for i := range w.Values {
+		start, end := w.Extent(i)
+		valMin = math.Min(valMin, math.Min(start, end))
+		valMax = math.Max(valMax, math.Max(start, end))
+	}
+	if !w.Horizontal {
+		return catMin, catMax, valMin, valMax
+	}
+	return valMin, valMax, catMin, catMax
+}
+
+// GlyphBoxes implements the GlyphBoxer interface.
+func (w *Waterfall) GlyphBoxes(plt *plot.Plot) []plot.GlyphBox {
+	boxes := make([]plot.GlyphBox, len(w.Values))
+	for i := range w.Values {
+		cat := w.XMin + float64(i)
+		if !w.Horizontal {
+			boxes[i].X = plt.X.Norm(cat)
+			boxes[i].Rectangle = vg.Rectangle{
+				Min: vg.Point{X: w.Offset - w.Width/2},
+				Max: vg.Point{X: w.Offset + w.Width/2},
+			}
+		} else {
+			boxes[i].Y = plt.Y.Norm(cat)
+			boxes[i].Rectangle = vg.Rectangle{
+				Min: vg.Point{Y: w.Offset - w.Width/2},
+				Max: vg.Point{Y: w.Offset + w.Width/2},
+			}
+		}
+	}
+	return boxes
+}
+
+// Thumbnail fulfills the plot.Thumbnailer interface.
+func (w *Waterfall) Thumbnail(c *draw.Canvas) {
+	drawBar(*c, false, c.Min.X, c.Max.X, c.Min.Y, c.Max.Y, w.IncreaseColor, w.LineStyle)
+}
+
+// ValueLabels returns a *Labels that will plot a label at
+// the end of each bar. The labels of changes are formatted
+// from the change value and those of subtotals from the
+// running total, using the given fmt package format
+// string. If format is empty, "%g" is used.
+func (w *Waterfall) ValueLabels(format string) (*Labels, error) {
+	if format == "" {
+		format = "%g"
+	}
+	var l XYLabels
+	for i, v := range w.Values {
+		_, end := w.Extent(i)
+		if w.isSubtotal(i) {
+			v = end
+		}
+		cat := w.XMin + float64(i)
+		pt := struct{ X, Y float64 }{X: cat, Y: end}
+		if w.Horizontal {
+			pt.X, pt.Y = end, cat
+		}
+		l.XYs = append(l.XYs, pt)
+		l.Labels = append(l.Labels, fmt.Sprintf(format, v))
+	}
+	labels, err := NewLabels(l)
+	if err != nil {
+		return nil, err
+	}
+
+	// Align each label so that it lies beyond the end of its bar.
+	for i := range labels.TextStyle {
+		start, end := w.Extent(i)
+		sty := &labels.TextStyle[i]
+		if !w.Horizontal {
+			sty.XAlign = draw.XCenter
+			sty.YAlign = draw.YBottom
+			if end < start {
+				sty.YAlign = draw.YTop
+			}
+		} else {
+			sty.YAlign = draw.YCenter
+			if end < start {
+				sty.XAlign = draw.XRight
+			}
+		}
+	}
+	if !w.Horizontal {
+		labels.XOffset = w.Offset
+	} else {
+		labels.YOffset = w.Offset
+	}
+	return labels, nil
+}
@@ -0,0 +1,68 @@
+// Copyright ©2016 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plotter
+
+import (
+	"log"
+	"testing"
+
+	"github.com/gonum/plot"
+	"github.com/gonum/plot/vg"
+)
+
+// ExampleWaterfall draws a waterfall chart of quarterly
+// changes with subtotals and value labels.
+func ExampleWaterfall() {
+	deltas := Values{120, 30, -45, 0, 60, -20, -15, 0}
+	names := []string{"Start", "Q1", "Q2", "H1", "Q3", "Q4", "Tax", "Total"}
+
+	p, err := plot.New()
+	if err != nil {
+		log.Panic(err)
+	}
+	p.Title.Text = "Waterfall"
+
+	w, err := NewWaterfall(deltas, vg.Points(20))
+	if err != nil {
+		log.Panic(err)
+	}
+	w.Subtotals = []int{3, 7}
+
+	labels, err := w.ValueLabels("%+.0f")
+	if err != nil {
+		log.Panic(err)
+	}
+
+	p.Add(w, labels)
+	p.NominalX(names...)
+
+	err = p.Save(300, 200, "testdata/waterfall.png")
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+func TestWaterfall(t *testing.T) {
+	checkPlot(ExampleWaterfall, t, "waterfall.png")
+}
+
+func TestWaterfallExtent(t *testing.T) {
+	w, err := NewWaterfall(Values{10, -4, 0, 3}, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	w.Subtotals = []int{2}
+	want := [][2]float64{{0, 10}, {10, 6}, {0, 6}, {6, 9}}
+	for i, e := range want {
+		start, end := w.Extent(i)
+		if start != e[0] || end != e[1] {
+			t.Errorf("unexpected extent for bar %d: got:[%v,%v] want:%v", i, start, end, e)
+		}
+	}
+	xmin, xmax, ymin, ymax := w.DataRange()
+	if xmin != 0 || xmax != 3 || ymin != 0 || ymax != 10 {
+		t.Errorf("unexpected data range: got:[%v,%v]×[%v,%v] want:[0,3]×[0,10]", xmin, xmax, ymin, ymax)
+	}
+}
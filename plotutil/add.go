@@ -275,11 +275,52 @@ func AddLinePoints(plt *plot.Plot, vs ...interface{}) error {
 // the Colors function corresponding to its position
 // in the argument list.
 //
+// An argument may instead be a *plotter.BarChart or
+// *plotter.Line followed by a plotter.YErrorer with a
+// Len method, such as plotter.YErrors, whose errors are
+// then set on the plotter as by SetYErrors,
+// so that they are drawn at the offsets of the bars.
+// The plotter itself is not added to the plot.
+//
 // If an error occurs then none of the plotters are added
-// to the plot, and the error is returned.
+// to the plot, no errors are set, and the error is returned.
 func AddErrorBars(plt *plot.Plot, vs ...interface{}) error {
 	var ps []plot.Plotter
-	for i, v := range vs {
+	// The errors of bar charts and lines are set once
+	// they have all been copied successfully.
+	var sets []func()
+	for i := 0; i < len(vs); i++ {
+		v := vs[i]
+		switch p := v.(type) {
+		case *plotter.BarChart, *plotter.Line:
+			if i+1 == len(vs) {
+				panic(fmt.Sprintf("AddErrorBars expects plotter.YErrorer after %T", v))
+			}
+			e, ok := vs[i+1].(interface {
+				plotter.YErrorer
+				Len() int
+			})
+			if !ok {
+				panic(fmt.Sprintf("AddErrorBars expects plotter.YErrorer after %T, got %T", v, vs[i+1]))
+			}
+			i++
+			switch p := p.(type) {
+			case *plotter.BarChart:
+				cpy := *p
+				if err := cpy.SetYErrors(e); err != nil {
+					return err
+				}
+				sets = append(sets, func() { p.YErrors = cpy.YErrors })
+			case *plotter.Line:
+				cpy := *p
+				if err := cpy.SetYErrors(e); err != nil {
+					return err
+				}
+				sets = append(sets, func() { p.YErrors = cpy.YErrors })
+			}
+			continue
+		}
+
 		added := false
 
 		if xerr, ok := v.(interface {
@@ -313,6 +354,9 @@ func AddErrorBars(plt *plot.Plot, vs ...interface{}) error {
 		}
 		panic(fmt.Sprintf("AddErrorBars expects plotter.XErrorer or plotter.YErrorer, got %T", v))
 	}
+	for _, set := range sets {
+		set()
+	}
 	plt.Add(ps...)
 	return nil
 }
@@ -19,6 +19,11 @@ type ErrorPoints struct {
 	plotter.YErrors
 }
 
+// Len returns the number of points.
+func (e *ErrorPoints) Len() int {
+	return len(e.XYs)
+}
+
 // NewErrorPoints returns a new ErrorPoints where each
 // point in the ErrorPoints is given by evaluating the
 // center function on the Xs and Ys for the corresponding
@@ -5,7 +5,9 @@
 package plotutil
 
 import (
+	"math"
 	"math/rand"
+	"testing"
 
 	"github.com/gonum/plot"
 	"github.com/gonum/plot/plotter"
@@ -55,3 +57,42 @@ func ExampleErrorPoints() {
 
 	plt.Save(4, 4, "centroids.png")
 }
+
+func TestAddErrorBarsSetsErrors(t *testing.T) {
+	plt, err := plot.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bars, err := plotter.NewBarChart(plotter.Values{1, 2}, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	line, err := plotter.NewLine(plotter.XYs{{0, 1}, {1, 2}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// An invalid error prevents any errors being set.
+	err = AddErrorBars(plt,
+		bars, plotter.YErrors{{1, 1}, {2, 2}},
+		line, plotter.YErrors{{1, 1}, {math.NaN(), 2}})
+	if err == nil {
+		t.Fatal("expected error for NaN error")
+	}
+	if bars.YErrors != nil || line.YErrors != nil {
+		t.Errorf("unexpected errors set after failure: bars:%v line:%v", bars.YErrors, line.YErrors)
+	}
+
+	err = AddErrorBars(plt,
+		bars, plotter.YErrors{{1, 1}, {2, 2}},
+		line, plotter.YErrors{{1, 1}, {3, 3}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bars.YErrors) != 2 || bars.YErrors[1].High != 2 {
+		t.Errorf("unexpected bar chart errors: %v", bars.YErrors)
+	}
+	if len(line.YErrors) != 2 || line.YErrors[1].High != 3 {
+		t.Errorf("unexpected line errors: %v", line.YErrors)
+	}
+}
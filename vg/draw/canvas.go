@@ -194,6 +194,20 @@ func (BoxGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt vg.Point) {
 	c.Fill(p)
 }
 
+// DiamondGlyph is a glyph that draws a filled diamond.
+type DiamondGlyph struct{}
+
+// DrawGlyph implements the Glyph interface.
+func (DiamondGlyph) DrawGlyph(c *Canvas, sty GlyphStyle, pt vg.Point) {
+	var p vg.Path
+	p.Move(vg.Point{X: pt.X, Y: pt.Y - sty.Radius})
+	p.Line(vg.Point{X: pt.X + sty.Radius, Y: pt.Y})
+	p.Line(vg.Point{X: pt.X, Y: pt.Y + sty.Radius})
+	p.Line(vg.Point{X: pt.X - sty.Radius, Y: pt.Y})
+	p.Close()
+	c.Fill(p)
+}
+
 // TriangleGlyph is a glyph that draws the outline of a triangle.
 type TriangleGlyph struct{}
 